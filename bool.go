@@ -0,0 +1,38 @@
+package csvtogo
+
+import (
+	"fmt"
+	"strings"
+)
+
+const tagBool = "bool"
+
+// parseBoolFormat split the format such as Y,yes|N,no into the true and false tokens
+func parseBoolFormat(format string) ([]string, []string, error) {
+	t, f, ok := strings.Cut(format, "|")
+	if !ok || strings.TrimSpace(t) == "" || strings.TrimSpace(f) == "" {
+		return nil, nil, fmt.Errorf("bool format must be true|false tokens such as Y|N, got: %v", format)
+	}
+	return strings.Split(t, ","), strings.Split(f, ","), nil
+}
+
+// parseBool return true or false of the token in format case-insensitively,
+// the value that is not a token is returned as is, then typeSafe parse it by strconv.ParseBool
+func parseBool(val, format string) (string, error) {
+	trueTokens, falseTokens, err := parseBoolFormat(format)
+	if err != nil {
+		return "", err
+	}
+	v := strings.TrimSpace(val)
+	for _, token := range trueTokens {
+		if strings.EqualFold(v, strings.TrimSpace(token)) {
+			return "true", nil
+		}
+	}
+	for _, token := range falseTokens {
+		if strings.EqualFold(v, strings.TrimSpace(token)) {
+			return "false", nil
+		}
+	}
+	return val, nil
+}
@@ -0,0 +1,101 @@
+package csvtogo
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+)
+
+func Test_parseBool(t *testing.T) {
+	tt := []struct {
+		name      string
+		val       string
+		format    string
+		expectedR string
+		expectedE error
+	}{
+		{name: "should return true of token", val: "Y", format: "Y|N", expectedR: "true"},
+		{name: "should match token case-insensitively", val: " no ", format: "Y,yes|N,no", expectedR: "false"},
+		{name: "should match thai token", val: "ไม่ใช่", format: "ใช่|ไม่ใช่", expectedR: "false"},
+		{name: "should return value as is when it's not a token", val: "true", format: "T|F", expectedR: "true"},
+		{name: "should return error when format has no false token", val: "Y", format: "Y|", expectedE: errors.New("bool format must be true|false tokens such as Y|N, got: Y|")},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			r, e := parseBool(tc.val, tc.format)
+			if fmt.Sprintf("%v", tc.expectedE) != fmt.Sprintf("%v", e) {
+				t.Errorf("must:%v, but got: %v", tc.expectedE, e)
+			}
+			if tc.expectedR != r {
+				t.Errorf("must:%v, but got: %v", tc.expectedR, r)
+			}
+		})
+	}
+}
+
+func Test_BoolFormat(t *testing.T) {
+	type Member struct {
+		Name   string `csv:"name"`
+		Active bool   `csv:"active"`
+		Thai   *bool  `csv:"thai" bool:"ใช่|ไม่ใช่"`
+	}
+	tt := []struct {
+		name      string
+		ops       *Options
+		content   string
+		expectedR string
+		expectedE error
+	}{
+		{
+			name:      "should parse bool by BoolFormat and bool tag",
+			ops:       &Options{SkipHeader: true, BoolFormat: "Y,yes,T|N,no,F"},
+			content:   "name,active,thai\na,y,ใช่\nb,No,ไม่ใช่\nc,t,\nd,false,\n",
+			expectedR: "[{a true true} {b false false} {c true <nil>} {d false <nil>}]",
+		},
+		{
+			name:      "should return error when value is not a token",
+			ops:       &Options{SkipHeader: true},
+			content:   "name,active,thai\na,true,Y\n",
+			expectedE: errors.New("invalid csv value at row: 1, the struct accept type bool"),
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			err := os.WriteFile("./bool_test.csv", []byte(tc.content), 0644)
+			if err != nil {
+				panic(err)
+			}
+			defer os.Remove("./bool_test.csv")
+
+			c, _ := NewClient[Member]("./bool_test.csv", tc.ops)
+			r, e := c.CsvToStruct()
+			if fmt.Sprintf("%v", tc.expectedE) != fmt.Sprintf("%v", e) {
+				t.Errorf("must:%v, but got: %v", tc.expectedE, e)
+			}
+			if tc.expectedE != nil {
+				return
+			}
+			var s []string
+			for _, v := range r {
+				thai := "<nil>"
+				if v.Thai != nil {
+					thai = fmt.Sprintf("%v", *v.Thai)
+				}
+				s = append(s, fmt.Sprintf("{%v %v %v}", v.Name, v.Active, thai))
+			}
+			if tc.expectedR != "["+strings.Join(s, " ")+"]" {
+				t.Errorf("must:%v, but got: %v", tc.expectedR, s)
+			}
+		})
+	}
+
+	t.Run("should return error when BoolFormat is invalid", func(t *testing.T) {
+		_, e := NewClient[Member]("./bool_test.csv", &Options{BoolFormat: "Y"})
+		expected := "bool format must be true|false tokens such as Y|N, got: Y"
+		if fmt.Sprintf("%v", e) != expected {
+			t.Errorf("must:%v, but got: %v", expected, e)
+		}
+	})
+}
@@ -1,14 +1,77 @@
 package csvtogo
 
 import (
+	"fmt"
+	"io"
+	"io/fs"
 	"os"
+	"path/filepath"
+	"reflect"
 )
 
 type Client[T any] struct {
 	Executor[T]
 }
 
+// FileError is returned by the client of NewMultiClient to tell which file is failed
+type FileError struct {
+	File string
+	Err  error
+}
+
+func (e *FileError) Error() string {
+	return fmt.Sprintf("%v: %v", e.File, e.Err)
+}
+
+func (e *FileError) Unwrap() error {
+	return e.Err
+}
+
 func NewClient[T any](file string, ops ...*Options) (*Client[T], error) {
+	return newClient[T](nil, []string{file}, false, ops...)
+}
+
+// NewClientFS read the file from fsys such as embed.FS or fstest.MapFS instead of local disk
+func NewClientFS[T any](fsys fs.FS, name string, ops ...*Options) (*Client[T], error) {
+	if fsys == nil {
+		return nil, fmt.Errorf("fsys is required")
+	}
+	return newClient[T](fsys, []string{name}, false, ops...)
+}
+
+// NewClientReader read csv from r such as request body or stdin, the content is streamed without buffering
+func NewClientReader[T any](r io.Reader, ops ...*Options) (*Client[T], error) {
+	if r == nil {
+		return nil, fmt.Errorf("reader is required")
+	}
+	c, err := newClient[T](nil, nil, false, ops...)
+	if err != nil {
+		return nil, err
+	}
+	c.src = r
+	return c, nil
+}
+
+// NewMultiClient read every file in order as one stream, file can be a glob pattern such as data_2024-01-*.csv
+func NewMultiClient[T any](files []string, ops ...*Options) (*Client[T], error) {
+	var paths []string
+	for _, pattern := range files {
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, err
+		}
+		if len(matches) == 0 {
+			return nil, fmt.Errorf("no file match with %v", pattern)
+		}
+		paths = append(paths, matches...)
+	}
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("file is required")
+	}
+	return newClient[T](nil, paths, true, ops...)
+}
+
+func newClient[T any](fsys fs.FS, files []string, multi bool, ops ...*Options) (*Client[T], error) {
 	option := _defaultOps
 	if ops != nil {
 		options := ops[0]
@@ -17,24 +80,103 @@ func NewClient[T any](file string, ops ...*Options) (*Client[T], error) {
 		option = *options
 	}
 
-	//validate file
-	f, err := os.Open(file)
+	//validate encoding
+	_, err := normalizeEncoding(option.Encoding)
 	if err != nil {
 		return nil, err
 	}
-	_ = f.Close()
 
-	return &Client[T]{
+	//validate format
+	switch option.Format {
+	case "", FormatCSV, FormatNDJSON, FormatXLSX:
+	default:
+		return nil, fmt.Errorf("format %v is not support", option.Format)
+	}
+
+	//validate number format
+	err = validNumberFormat(option.NumberFormat)
+	if err != nil {
+		return nil, err
+	}
+
+	//validate bool format
+	if option.BoolFormat != "" {
+		_, _, err = parseBoolFormat(option.BoolFormat)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	//validate struct
+	err = validStruct(reflect.TypeOf((*T)(nil)).Elem())
+	if err != nil {
+		return nil, err
+	}
+
+	//validate schema
+	if option.Schema != nil {
+		err = option.Schema.validateFields(reflect.TypeOf((*T)(nil)).Elem())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	//validate file
+	for _, file := range files {
+		f, err := openFile(fsys, file)
+		if err != nil {
+			return nil, err
+		}
+		_ = f.Close()
+	}
+
+	option.stats = &readStats{}
+
+	c := &Client[T]{
 		Executor[T]{
-			file:     file,
+			fsys:     fsys,
+			files:    files,
+			multi:    multi,
 			ops:      option,
 			outsChan: make(chan []T, 1),
-			outChan:  make(chan T, 1),
+			outChan:  make(chan rowValue[T], 1),
 			nextChan: make(chan bool, 1),
 			errChan:  make(chan error),
+			done:     make(chan struct{}),
 			run:      true,
 		},
-	}, nil
+	}
+	if option.Schema != nil && !option.SkipHeader {
+		//no header, map column by index of schema
+		t := reflect.TypeOf((*T)(nil)).Elem()
+		if t.Kind() == reflect.Struct && t != reflect.TypeOf(Record{}) {
+			c.applySchema(t, nil)
+		}
+	}
+	return c, nil
+}
+
+// WithFilter keep the row if fn return true, fn is evaluated after conversion and before validation, the rejected row is counted in Stats.
+// The csv rows are converted concurrently, fn may be called by many goroutines at once and not in the order of the file,
+// then fn must be safe for concurrent use. Use RawFilter for the filter that depend on the previous rows such as drop duplicates
+func (c *Client[T]) WithFilter(fn func(*T) bool) *Client[T] {
+	c.filter = fn
+	return c
+}
+
+// WithPostTransform change the value by fn after conversion before the filter and validation, the error is returned with the row number.
+// The csv rows are converted concurrently, fn may be called by many goroutines at once and not in the order of the file,
+// then fn must be safe for concurrent use
+func (c *Client[T]) WithPostTransform(fn func(*T) error) *Client[T] {
+	c.post = fn
+	return c
+}
+
+func openFile(fsys fs.FS, file string) (io.ReadCloser, error) {
+	if fsys != nil {
+		return fsys.Open(file)
+	}
+	return os.Open(file)
 }
 
 func initSkipper(skipCols []int) map[int]int {
@@ -7,6 +7,7 @@ import (
 	"os"
 	"strings"
 	"testing"
+	"testing/fstest"
 )
 
 func Test_NewClient(t *testing.T) {
@@ -130,3 +131,145 @@ func Test_initSkipper(t *testing.T) {
 		})
 	}
 }
+
+func Test_NewMultiClient(t *testing.T) {
+	type Student struct {
+		Firstname string
+	}
+	genFile := func(name string) {
+		f, err := os.Create(name)
+		if err != nil {
+			panic(err)
+		}
+		w := csv.NewWriter(f)
+		_ = w.Write([]string{"Firstname"})
+		_ = w.Write([]string{strings.Repeat("e", 5)})
+		w.Flush()
+		_ = f.Close()
+	}
+	tt := []struct {
+		name      string
+		files     []string
+		genFile   func()
+		expectedR []string
+		expectedE error
+	}{
+		{
+			name:  "should return valid result when glob match some files",
+			files: []string{"./multi_client_test_*.csv"},
+			genFile: func() {
+				genFile("./multi_client_test_2.csv")
+				genFile("./multi_client_test_1.csv")
+			},
+			expectedR: []string{"multi_client_test_1.csv", "multi_client_test_2.csv"},
+			expectedE: nil,
+		},
+		{
+			name:  "should return valid result when files are plain paths",
+			files: []string{"./multi_client_test_2.csv", "./multi_client_test_1.csv"},
+			genFile: func() {
+				genFile("./multi_client_test_2.csv")
+				genFile("./multi_client_test_1.csv")
+			},
+			expectedR: []string{"./multi_client_test_2.csv", "./multi_client_test_1.csv"},
+			expectedE: nil,
+		},
+		{
+			name:      "should return nil and err when glob does not match any file",
+			files:     []string{"./multi_client_test_*.csv"},
+			genFile:   nil,
+			expectedR: nil,
+			expectedE: errors.New("no file match with ./multi_client_test_*.csv"),
+		},
+		{
+			name:      "should return nil and err when files is empty",
+			files:     nil,
+			genFile:   nil,
+			expectedR: nil,
+			expectedE: errors.New("file is required"),
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.genFile != nil {
+				tc.genFile()
+			}
+			r, e := NewMultiClient[Student](tc.files)
+			if fmt.Sprintf("%v", tc.expectedE) != fmt.Sprintf("%v", e) {
+				t.Errorf("must:%v, but got: %v", tc.expectedE, e)
+			}
+			if r != nil {
+				if fmt.Sprintf("%v", tc.expectedR) != fmt.Sprintf("%v", r.files) {
+					t.Errorf("must:%v, but got: %v", tc.expectedR, r.files)
+				}
+				r.Close()
+			}
+			_ = os.Remove("./multi_client_test_1.csv")
+			_ = os.Remove("./multi_client_test_2.csv")
+		})
+	}
+}
+
+func Test_NewClientFS(t *testing.T) {
+	type Student struct {
+		Firstname string
+		Age       int
+	}
+	fsys := fstest.MapFS{
+		"data/student.csv": &fstest.MapFile{Data: []byte("FIRSTNAME,AGE\nSarah,12\n")},
+	}
+	tt := []struct {
+		name      string
+		fsys      fstest.MapFS
+		file      string
+		expectedR []Student
+		expectedE error
+	}{
+		{
+			name:      "should return valid result when file is in fsys",
+			fsys:      fsys,
+			file:      "data/student.csv",
+			expectedR: []Student{{Firstname: "Sarah", Age: 12}},
+			expectedE: nil,
+		},
+		{
+			name:      "should return nil and err when file is not found in fsys",
+			fsys:      fsys,
+			file:      "data/other.csv",
+			expectedR: nil,
+			expectedE: errors.New("open data/other.csv: file does not exist"),
+		},
+		{
+			name:      "should return nil and err when fsys is nil",
+			fsys:      nil,
+			file:      "data/student.csv",
+			expectedR: nil,
+			expectedE: errors.New("fsys is required"),
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var c *Client[Student]
+			var e error
+			if tc.fsys != nil {
+				c, e = NewClientFS[Student](tc.fsys, tc.file)
+			} else {
+				c, e = NewClientFS[Student](nil, tc.file)
+			}
+			if fmt.Sprintf("%v", tc.expectedE) != fmt.Sprintf("%v", e) {
+				t.Errorf("must:%v, but got: %v", tc.expectedE, e)
+			}
+			if c == nil {
+				return
+			}
+			r, err := c.CsvToStruct()
+			if err != nil {
+				t.Errorf("must:nil, but got: %v", err)
+			}
+			err = deepEqual[Student](tc.expectedR, r)
+			if err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
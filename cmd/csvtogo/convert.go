@@ -0,0 +1,65 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/rkritchat/csvtogo"
+)
+
+func convert(args []string) int {
+	fs := flag.NewFlagSet("convert", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: csvtogo convert [flags] <file>")
+		fmt.Fprintln(fs.Output(), "Read from stdin if file is -.")
+		fs.PrintDefaults()
+	}
+	var rf readerFlags
+	rf.register(fs)
+	ndjson := fs.Bool("ndjson", false, "write one JSON object per line instead of JSON array")
+	schemaFile := fs.String("schema", "", "JSON or YAML schema file to type and validate the values")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return 2
+	}
+
+	ops, err := rf.options()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+	if *schemaFile != "" {
+		ops.Schema, err = csvtogo.LoadSchema(*schemaFile)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 2
+		}
+	}
+
+	var r io.Reader = os.Stdin
+	if fs.Arg(0) != "-" {
+		f, err := os.Open(fs.Arg(0))
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+		defer f.Close()
+		r = f
+	}
+
+	if *ndjson {
+		err = csvtogo.ConvertToNDJSON(r, os.Stdout, ops)
+	} else {
+		err = csvtogo.ConvertToJSON(r, os.Stdout, ops)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	return 0
+}
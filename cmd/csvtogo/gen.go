@@ -0,0 +1,49 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/rkritchat/csvtogo"
+)
+
+func gen(args []string) int {
+	fs := flag.NewFlagSet("gen", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: csvtogo gen [flags] <file>")
+		fs.PrintDefaults()
+	}
+	var rf readerFlags
+	rf.register(fs)
+	name := fs.String("name", "Row", "name of the struct")
+	pkg := fs.String("package", "", "print package clause and imports as well if set")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return 2
+	}
+
+	ops, err := rf.options()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+	src, err := csvtogo.GenerateStruct(fs.Arg(0), *name, ops)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	if *pkg != "" {
+		fmt.Printf("package %v\n\n", *pkg)
+		if strings.Contains(src, "time.Time") {
+			fmt.Print("import \"time\"\n\n")
+		}
+	}
+	fmt.Print(src)
+	return 0
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+const usage = `csvtogo is the command line tool of github.com/rkritchat/csvtogo
+
+Usage:
+	csvtogo <command> [flags] <file>
+
+The commands are:
+	convert    convert csv to JSON array or NDJSON
+	gen        generate Go struct from the sample csv file
+	inspect    print the profile of each column such as type, null ratio, min/max
+	validate   validate every row by schema and print every violation
+
+Use "csvtogo <command> -h" for more information about a command.
+`
+
+// commands is the list of sub command, each command return the exit code
+var commands = map[string]func(args []string) int{
+	"convert":  convert,
+	"gen":      gen,
+	"inspect":  inspect,
+	"validate": validate,
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+	cmd, ok := commands[os.Args[1]]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "csvtogo: unknown command %v\n\n%v", os.Args[1], usage)
+		os.Exit(2)
+	}
+	os.Exit(cmd(os.Args[2:]))
+}
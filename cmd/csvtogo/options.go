@@ -0,0 +1,55 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/rkritchat/csvtogo"
+)
+
+// readerFlags are the flags that every command use to read csv file
+type readerFlags struct {
+	comma    string
+	noHeader bool
+	skipCols string
+	encoding string
+	format   string
+	sheet    string
+}
+
+func (f *readerFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&f.comma, "comma", ",", "field delimiter")
+	fs.BoolVar(&f.noHeader, "no-header", false, "the first row is not header")
+	fs.StringVar(&f.skipCols, "skip", "", "comma separated index of columns to skip such as 0,3")
+	fs.StringVar(&f.encoding, "encoding", "", "file encoding, auto detect BOM if empty")
+	fs.StringVar(&f.format, "format", "", "csv, ndjson or xlsx, detect by file extension if empty")
+	fs.StringVar(&f.sheet, "sheet", "", "sheet name of xlsx file, the first sheet if empty")
+}
+
+func (f *readerFlags) options() (*csvtogo.Options, error) {
+	comma, size := utf8.DecodeRuneInString(f.comma)
+	if comma == utf8.RuneError || size != len(f.comma) {
+		return nil, fmt.Errorf("comma must be a single character, got: %v", f.comma)
+	}
+	var skipCols []int
+	if f.skipCols != "" {
+		for _, s := range strings.Split(f.skipCols, ",") {
+			i, err := strconv.Atoi(strings.TrimSpace(s))
+			if err != nil {
+				return nil, fmt.Errorf("skip must be comma separated integer, got: %v", f.skipCols)
+			}
+			skipCols = append(skipCols, i)
+		}
+	}
+	return &csvtogo.Options{
+		SkipHeader: !f.noHeader,
+		Comma:      comma,
+		SkipCols:   skipCols,
+		Encoding:   f.encoding,
+		Format:     f.format,
+		Sheet:      f.sheet,
+	}, nil
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/rkritchat/csvtogo"
+)
+
+func validate(args []string) int {
+	fs := flag.NewFlagSet("validate", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: csvtogo validate [flags] <file>")
+		fmt.Fprintln(fs.Output(), "Exit status is 0 if file is valid, 1 if some violation is found and 2 if error.")
+		fs.PrintDefaults()
+	}
+	var rf readerFlags
+	rf.register(fs)
+	schemaFile := fs.String("schema", "", "JSON or YAML schema file")
+	sample := fs.String("sample", "", "infer the rules from the sample csv file instead of schema")
+	asJSON := fs.Bool("json", false, "print violations as JSON")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() != 1 || (*schemaFile == "") == (*sample == "") {
+		fmt.Fprintln(fs.Output(), "file and either -schema or -sample are required")
+		fs.Usage()
+		return 2
+	}
+
+	ops, err := rf.options()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+	var schema *csvtogo.Schema
+	if *schemaFile != "" {
+		schema, err = csvtogo.LoadSchema(*schemaFile)
+	} else {
+		schema, err = csvtogo.InferSchema(*sample, ops)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+
+	violations, err := csvtogo.Validate(fs.Arg(0), schema, ops)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+
+	if *asJSON {
+		if violations == nil {
+			violations = []csvtogo.Violation{}
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		_ = enc.Encode(struct {
+			File       string              `json:"file"`
+			Valid      bool                `json:"valid"`
+			Violations []csvtogo.Violation `json:"violations"`
+		}{fs.Arg(0), len(violations) == 0, violations})
+	} else {
+		for _, v := range violations {
+			if v.Column == "" {
+				//the row can't be parsed
+				fmt.Printf("%v:%v: %v\n", fs.Arg(0), v.Row, v.Message)
+				continue
+			}
+			fmt.Printf("%v:%v: column %v: %v\n", fs.Arg(0), v.Row, v.Column, v.Message)
+		}
+		if len(violations) > 0 {
+			fmt.Fprintf(os.Stderr, "%v violation(s) found\n", len(violations))
+		}
+	}
+	if len(violations) > 0 {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,139 @@
+package csvtogo
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"compress/bzip2"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+var (
+	magicGzip  = []byte{0x1F, 0x8B}
+	magicBzip2 = []byte("BZh")
+	magicZip   = []byte("PK\x03\x04")
+	magicZstd  = []byte{0x28, 0xB5, 0x2F, 0xFD}
+)
+
+// dataExts are the extensions of entry that read from zip archive when ZipEntry is empty
+var dataExts = map[string]bool{
+	".csv":    true,
+	".jsonl":  true,
+	".ndjson": true,
+}
+
+// entry is one csv content inside the file, plain or compressed file has only one entry
+type entry struct {
+	name    string
+	archive bool
+	open    func() (io.ReadCloser, error)
+}
+
+func (e entry) read(fn func(r io.Reader) error) error {
+	r, err := e.open()
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	err = fn(r)
+	if err != nil && e.archive {
+		//let the client know which entry is failed
+		return fmt.Errorf("%v: %w", e.name, err)
+	}
+	return err
+}
+
+// openEntries detect compression from magic bytes or file extension and return the entries to read
+func openEntries(r io.Reader, name, zipEntry string) ([]entry, error) {
+	br := bufio.NewReader(r)
+	head, _ := br.Peek(4)
+	ext := strings.ToLower(path.Ext(name))
+
+	switch {
+	case bytes.HasPrefix(head, magicZip) || ext == ".zip":
+		return zipEntries(br, r, zipEntry)
+	case bytes.HasPrefix(head, magicGzip) || ext == ".gz":
+		return []entry{{name: name, open: func() (io.ReadCloser, error) {
+			return gzip.NewReader(br)
+		}}}, nil
+	case isBzip2(head) || ext == ".bz2":
+		return []entry{{name: name, open: func() (io.ReadCloser, error) {
+			return io.NopCloser(bzip2.NewReader(br)), nil
+		}}}, nil
+	case bytes.HasPrefix(head, magicZstd) || ext == ".zst":
+		return []entry{{name: name, open: func() (io.ReadCloser, error) {
+			d, err := zstd.NewReader(br)
+			if err != nil {
+				return nil, err
+			}
+			return d.IOReadCloser(), nil
+		}}}, nil
+	}
+	return []entry{{name: name, open: func() (io.ReadCloser, error) {
+		return io.NopCloser(br), nil
+	}}}, nil
+}
+
+// isBzip2 check the magic BZh follow by block size from 1 to 9, then the plain text such as BZh,AGE is not bzip2
+func isBzip2(head []byte) bool {
+	return bytes.HasPrefix(head, magicBzip2) && len(head) > 3 && head[3] >= '1' && head[3] <= '9'
+}
+
+func zipEntries(br *bufio.Reader, r io.Reader, zipEntry string) ([]entry, error) {
+	//zip need random access, read whole content into memory if r is not support
+	ra, size, err := readerAt(br, r)
+	if err != nil {
+		return nil, err
+	}
+	zr, err := zip.NewReader(ra, size)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []entry
+	for _, f := range zr.File {
+		f := f
+		if f.FileInfo().IsDir() {
+			continue
+		}
+		if zipEntry != "" && f.Name != zipEntry {
+			continue
+		}
+		if zipEntry == "" && !dataExts[strings.ToLower(path.Ext(f.Name))] {
+			continue
+		}
+		entries = append(entries, entry{name: f.Name, archive: true, open: f.Open})
+	}
+	if len(entries) == 0 {
+		if zipEntry != "" {
+			return nil, fmt.Errorf("entry %v is not found in zip archive", zipEntry)
+		}
+		return nil, fmt.Errorf("no csv entry found in zip archive")
+	}
+	return entries, nil
+}
+
+func readerAt(br *bufio.Reader, r io.Reader) (io.ReaderAt, int64, error) {
+	if f, ok := r.(interface {
+		io.ReaderAt
+		io.Seeker
+	}); ok {
+		size, err := f.Seek(0, io.SeekEnd)
+		if err != nil {
+			return nil, 0, err
+		}
+		return f, size, nil
+	}
+	b, err := io.ReadAll(br)
+	if err != nil {
+		return nil, 0, err
+	}
+	return bytes.NewReader(b), int64(len(b)), nil
+}
@@ -0,0 +1,173 @@
+package csvtogo
+
+import (
+	"archive/zip"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// sample.csv.bz2 of "NAME,AGE\nSarah,12\n", stdlib doesn't provide bzip2 writer
+var bzip2Sample = []byte{
+	0x42, 0x5a, 0x68, 0x39, 0x31, 0x41, 0x59, 0x26, 0x53, 0x59, 0xd1, 0x19,
+	0x0e, 0x36, 0x00, 0x00, 0x06, 0x5f, 0x80, 0x00, 0x10, 0x00, 0x04, 0x30,
+	0x00, 0x22, 0x83, 0x08, 0x00, 0x20, 0x40, 0x10, 0x00, 0x20, 0x00, 0x21,
+	0xa0, 0x23, 0x68, 0x08, 0x06, 0x80, 0x1e, 0x71, 0x62, 0x54, 0xd1, 0x20,
+	0x8d, 0xcd, 0x1c, 0x2e, 0xe4, 0x8a, 0x70, 0xa1, 0x21, 0xa2, 0x32, 0x1c,
+	0x6c,
+}
+
+func genZip(filename string, files map[string]string, order []string) {
+	f, err := os.Create(filename)
+	if err != nil {
+		panic(err)
+	}
+	w := zip.NewWriter(f)
+	for _, name := range order {
+		fw, err := w.Create(name)
+		if err != nil {
+			panic(err)
+		}
+		_, _ = fw.Write([]byte(files[name]))
+	}
+	_ = w.Close()
+	_ = f.Close()
+}
+
+func Test_CsvToStruct_compressed(t *testing.T) {
+	type Customer struct {
+		Name string
+		Age  int
+	}
+	tt := []struct {
+		name      string
+		filename  string
+		ops       *Options
+		genFile   func()
+		expectedR []Customer
+		expectedE error
+	}{
+		{
+			name:     "should return valid result when file is gzip",
+			filename: "./compress_test.csv.gz",
+			genFile: func() {
+				f, err := os.Create("./compress_test.csv.gz")
+				if err != nil {
+					panic(err)
+				}
+				w := gzip.NewWriter(f)
+				_, _ = w.Write([]byte("NAME,AGE\nSarah,12\n"))
+				_ = w.Close()
+				_ = f.Close()
+			},
+			expectedR: []Customer{{Name: "Sarah", Age: 12}},
+			expectedE: nil,
+		},
+		{
+			name:     "should detect bzip2 from magic bytes",
+			filename: "./compress_test.dat",
+			genFile: func() {
+				_ = os.WriteFile("./compress_test.dat", bzip2Sample, 0644)
+			},
+			expectedR: []Customer{{Name: "Sarah", Age: 12}},
+			expectedE: nil,
+		},
+		{
+			name:     "should read plain file when it starts with BZh but no block size",
+			filename: "./compress_test.dat",
+			genFile: func() {
+				_ = os.WriteFile("./compress_test.dat", []byte("BZh,AGE\nSarah,12\n"), 0644)
+			},
+			expectedR: []Customer{{Name: "Sarah", Age: 12}},
+			expectedE: nil,
+		},
+		{
+			name:     "should read every csv entry in zip archive",
+			filename: "./compress_test.zip",
+			genFile: func() {
+				genZip("./compress_test.zip", map[string]string{
+					"a.csv":     "NAME,AGE\nSarah,12\n",
+					"readme.md": "not csv",
+					"b.csv":     "NAME,AGE\nJohn,21\n",
+				}, []string{"a.csv", "readme.md", "b.csv"})
+			},
+			expectedR: []Customer{{Name: "Sarah", Age: 12}, {Name: "John", Age: 21}},
+			expectedE: nil,
+		},
+		{
+			name:     "should read only named entry when ZipEntry is set",
+			filename: "./compress_test.zip",
+			ops:      &Options{SkipHeader: true, ZipEntry: "b.csv"},
+			genFile: func() {
+				genZip("./compress_test.zip", map[string]string{
+					"a.csv": "NAME,AGE\nSarah,12\n",
+					"b.csv": "NAME,AGE\nJohn,21\n",
+				}, []string{"a.csv", "b.csv"})
+			},
+			expectedR: []Customer{{Name: "John", Age: 21}},
+			expectedE: nil,
+		},
+		{
+			name:     "should return err with entry name when entry is invalid",
+			filename: "./compress_test.zip",
+			genFile: func() {
+				genZip("./compress_test.zip", map[string]string{
+					"a.csv": "NAME,AGE\nSarah,x\n",
+				}, []string{"a.csv"})
+			},
+			expectedR: nil,
+			expectedE: errors.New("a.csv: invalid csv value at row: 1, the struct accept type int"),
+		},
+		{
+			name:     "should return err when ZipEntry is not found",
+			filename: "./compress_test.zip",
+			ops:      &Options{SkipHeader: true, ZipEntry: "c.csv"},
+			genFile: func() {
+				genZip("./compress_test.zip", map[string]string{
+					"a.csv": "NAME,AGE\nSarah,12\n",
+				}, []string{"a.csv"})
+			},
+			expectedR: nil,
+			expectedE: errors.New("entry c.csv is not found in zip archive"),
+		},
+		{
+			name:     "should detect zstd from magic bytes",
+			filename: "./compress_test.dat",
+			genFile: func() {
+				f, err := os.Create("./compress_test.dat")
+				if err != nil {
+					panic(err)
+				}
+				w, _ := zstd.NewWriter(f)
+				_, _ = w.Write([]byte("NAME,AGE\nSarah,12\n"))
+				_ = w.Close()
+				_ = f.Close()
+			},
+			expectedR: []Customer{{Name: "Sarah", Age: 12}},
+			expectedE: nil,
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.genFile()
+			var ops []*Options
+			if tc.ops != nil {
+				ops = append(ops, tc.ops)
+			}
+			c, _ := NewClient[Customer](tc.filename, ops...)
+			r, e := c.CsvToStruct()
+			if fmt.Sprintf("%v", tc.expectedE) != fmt.Sprintf("%v", e) {
+				t.Errorf("must:%v, but got: %v", tc.expectedE, e)
+			}
+			err := deepEqual[Customer](tc.expectedR, r)
+			if err != nil {
+				t.Error(err)
+			}
+			_ = os.Remove(tc.filename)
+		})
+	}
+}
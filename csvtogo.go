@@ -1,47 +1,81 @@
 package csvtogo
 
 import (
+	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"reflect"
 	"strconv"
+	"strings"
+	"time"
 )
 
+// errClosed stop the reader when client is closed before every row is read
+var errClosed = errors.New("csvtogo: client is closed")
+
 var _defaultOps = Options{
 	SkipHeader: true,
 	Comma:      ',',
 }
 
 type Executor[T any] struct {
-	file     string
+	src      io.Reader
+	srcName  string //name of src such as the uploaded file name, used to detect format
+	fsys     fs.FS
+	files    []string
+	multi    bool
 	outsChan chan []T
-	outChan  chan T
+	outChan  chan rowValue[T]
 	nextChan chan bool
 	errChan  chan error
+	done     chan struct{} //closed by Close, the reader stop sending
 	run      bool
 	ops      Options
+	columns  []int                              //column to field index when map by header, nil if map by order
+	setter   func(T, []string, int) (*T, error) //replace valueSetter such as dynamic record
+	setJSON  func(T, []byte, int) (*T, error)   //replace jsonSetter such as dynamic record
+	err      error                              //error that received before the last row is read
+	rules    map[string]*SchemaColumn           //schema rules of each field path
+	keys     []string                           //keys of the last NDJSON object, the mapping is rebuilt when changed
+	row      int                                //row number of the last value returned by Read
+	filter   func(*T) bool                      //Client.WithFilter
+	post     func(*T) error                     //Client.WithPostTransform
+	format   string                             //format of the entry that is reading such as FormatXLSX
+}
+
+// rowValue is the value that sent to client with its row number
+type rowValue[T any] struct {
+	value T
+	row   int
 }
 
 type Options struct {
-	SkipHeader bool
-	SkipCols   []int
-	Comma      rune
-	ChunkSize  int
-	skipper    map[int]int
+	SkipHeader       bool
+	SkipCols         []int
+	Comma            rune
+	Comment          rune //line beginning with the Comment character is ignored
+	LazyQuotes       bool
+	TrimLeadingSpace bool
+	TrimSpace        bool //trim leading and trailing space of every value, the trim tag of field override it
+	FieldsPerRecord  int  //same as csv.Reader, negative value allow variable number of fields per row
+	ChunkSize        int
+	Encoding         string //auto detect BOM if empty, see Encoding* for supported encoding
+	ZipEntry         string //name of csv in zip archive, read every .csv, .jsonl and .ndjson entry if empty
+	Format           string //FormatCSV, FormatNDJSON or FormatXLSX, detect by file extension if empty
+	Sheet            string //name of sheet in xlsx file, read the first sheet if empty
+	NumberFormat     string //NumberEN, NumberEU, NumberFR or NumberCH, the default of number tag, numbers are in Go syntax if empty
+	BoolFormat       string //true|false tokens such as Y,yes|N,no, the default of bool tag, matched case-insensitively
+	Schema           *Schema
+	RawFilter        func([]string) bool              //keep the row if true, evaluated on the raw record before conversion one row at a time in the order of the file, not used with NDJSON
+	PreTransform     func([]string) ([]string, error) //change the raw record before conversion, not used with NDJSON, called concurrently and must be safe for concurrent use
+	skipper          map[int]int
+	stats            *readStats
+	invalidRow       func(*RowError) //report the invalid row and continue instead of stopping, see Validate and Load
 }
 
 func (c *Executor[T]) CsvToRows() *Executor[T] {
-	go func() {
-		err := csvReader[T](
-			c.file,
-			c.ops.Comma,
-			c.valueSetter,
-		)
-		if err != nil {
-			c.errChan <- err
-			return
-		}
-	}()
+	go c.read()
 	return c
 }
 
@@ -64,14 +98,47 @@ func (c *Executor[T]) CsvToStruct() ([]*T, error) {
 }
 
 func (c *Executor[T]) read() {
-	err := csvReader[T](
-		c.file,
-		c.ops.Comma,
-		c.valueSetter,
-	)
-	if err != nil {
-		c.errChan <- err
-		return
+	s := setters[T]{value: c.valueSetter, json: c.jsonSetter, send: c.send, begin: c.beginEntry}
+	if c.setter != nil {
+		s.value, s.json = c.setter, c.setJSON
+	}
+	if c.src != nil {
+		err := streamReader[T](c.src, c.srcName, c.ops, s)
+		if err != nil {
+			c.finish(err)
+			return
+		}
+	}
+	for _, file := range c.files {
+		err := fileReader[T](
+			c.fsys,
+			file,
+			c.ops,
+			s,
+		)
+		if err != nil {
+			if c.multi {
+				err = &FileError{File: file, Err: err}
+			}
+			c.finish(err)
+			return
+		}
+	}
+	c.finish(io.EOF)
+}
+
+// beginEntry keep the format of the entry that is reading, then force jsonSetter to map the keys again,
+// the columns may be overwritten by the header of other entry such as csv in the same zip
+func (c *Executor[T]) beginEntry(format string) {
+	c.format = format
+	c.keys = nil
+}
+
+// finish send the last error to client, nothing is sent when the client is closed
+func (c *Executor[T]) finish(err error) {
+	select {
+	case c.errChan <- err:
+	case <-c.done:
 	}
 }
 
@@ -84,11 +151,26 @@ func (c *Executor[T]) Next() bool {
 }
 
 func (c *Executor[T]) Read() (*T, error) {
+	if c.err != nil {
+		c.run = false
+		return nil, c.err
+	}
 	for c.run {
 		select {
 		case data := <-c.outChan:
-			return &data, nil
+			c.row = data.row
+			c.ops.stats.returnRow()
+			return &data.value, nil
 		case err := <-c.errChan:
+			select {
+			case data := <-c.outChan:
+				//the last row is not read yet, return error at next read
+				c.err = err
+				c.row = data.row
+				c.ops.stats.returnRow()
+				return &data.value, nil
+			default:
+			}
 			c.run = false
 			return nil, err
 		default:
@@ -98,34 +180,227 @@ func (c *Executor[T]) Read() (*T, error) {
 	return nil, nil
 }
 
+// Row return the row number of the value that returned by the last Read, such as the line of csv
+// or the row of spreadsheet, then the client can report the invalid row back to the sender.
+func (c *Executor[T]) Row() int {
+	return c.row
+}
+
 func (c *Executor[T]) setValue(data []string, tmp *T, row int) error {
+	return c.setValues(data, tmp, row, c.ops.skipper)
+}
+
+// setValues set each column of data to the field of tmp, the column in skipper is ignored
+func (c *Executor[T]) setValues(data []string, tmp *T, row int, skipper map[int]int) error {
 	col := 0
+	v := reflect.ValueOf(tmp).Elem()
+	fields := structFields(v.Type())
+	vf := c.ops.valueFormat()
+	vf.xlsx = c.format == FormatXLSX
 
 	for i, val := range data {
 		//check if in skipper
-		if _, ok := c.ops.skipper[i]; ok {
+		if _, ok := skipper[i]; ok {
 			continue
 		}
 
-		f := reflect.ValueOf(tmp).Elem().Field(col)
-		err := typeSafe(f, val, row)
+		idx := col
+		if c.columns != nil {
+			//map by header
+			if i >= len(c.columns) || c.columns[i] < 0 {
+				continue
+			}
+			idx = c.columns[i]
+		} else if col >= len(fields) {
+			//variable fields, ignore the rest of columns
+			break
+		}
+		val, err := normalize(fields[idx].field, fields[idx].path, val, c.ops.TrimSpace)
 		if err != nil {
-			return err
+			return rowError(row, fields[idx].name, val, err)
+		}
+		if rule := c.rules[fields[idx].path]; rule != nil && rule.isNull(val) {
+			//keep zero value
+			col += 1
+			continue
+		}
+		f := fieldByIndex(v, fields[idx].index)
+		err = setField(f, fields[idx].field, val, row, vf)
+		if err != nil {
+			return rowError(row, fields[idx].name, val, err)
 		}
 		col += 1
 	}
 	return nil
 }
 
-func (c *Executor[T]) send(out *T) {
-	c.outChan <- *out
-	<-c.nextChan //w8 until client is ready to move
+// send the value to client, errClosed is returned when the client is closed then the reader stop reading
+func (c *Executor[T]) send(out *T, row int) error {
+	select {
+	case <-c.done:
+		return errClosed
+	default:
+	}
+	select {
+	case c.outChan <- rowValue[T]{value: *out, row: row}:
+	case <-c.done:
+		return errClosed
+	}
+	select {
+	case <-c.nextChan: //w8 until client is ready to move
+	case <-c.done:
+		return errClosed
+	}
+	return nil
+}
+
+// setField set val to f, slice and map are split by split and kv tag then each element is set by convert
+func setField(f reflect.Value, sf reflect.StructField, val string, row int, vf valueFormat) error {
+	switch f.Kind() {
+	case reflect.Ptr:
+		if val == "" {
+			//nullable field, keep nil
+			return nil
+		}
+		p := reflect.New(f.Type().Elem())
+		err := setField(p.Elem(), sf, val, row, vf)
+		if err != nil {
+			return err
+		}
+		f.Set(p)
+		return nil
+	case reflect.Slice:
+		if val == "" {
+			return nil
+		}
+		items := strings.Split(val, splitTag(sf))
+		s := reflect.MakeSlice(f.Type(), 0, len(items))
+		for _, item := range items {
+			e := reflect.New(f.Type().Elem()).Elem()
+			err := convert(e, sf, trimItem(sf, item, vf.trim), row, vf)
+			if err != nil {
+				return err
+			}
+			s = reflect.Append(s, e)
+		}
+		f.Set(s)
+		return nil
+	case reflect.Map:
+		if val == "" {
+			return nil
+		}
+		kv := sf.Tag.Get(tagKv)
+		if kv == "" {
+			kv = defaultKv
+		}
+		m := reflect.MakeMap(f.Type())
+		for _, item := range strings.Split(val, splitTag(sf)) {
+			pair := strings.SplitN(item, kv, 2)
+			if len(pair) != 2 {
+				return fmt.Errorf("invalid csv value at row: %v, %v is not a key%vvalue pair", row, item, kv)
+			}
+			k := reflect.New(f.Type().Key()).Elem()
+			err := convert(k, sf, trimItem(sf, pair[0], vf.trim), row, vf)
+			if err != nil {
+				return err
+			}
+			e := reflect.New(f.Type().Elem()).Elem()
+			err = convert(e, sf, trimItem(sf, pair[1], vf.trim), row, vf)
+			if err != nil {
+				return err
+			}
+			m.SetMapIndex(k, e)
+		}
+		f.Set(m)
+		return nil
+	}
+	return convert(f, sf, val, row, vf)
+}
+
+// convert set the single value to f, the type that depend on tag is done here, otherwise typeSafe
+func convert(f reflect.Value, sf reflect.StructField, val string, row int, vf valueFormat) error {
+	switch f.Interface().(type) {
+	case time.Time:
+		switch unit := sf.Tag.Get(tagTime); unit {
+		case "":
+		case timeUnix, timeUnixMs:
+			t, err := parseUnix(val, unit)
+			if err != nil {
+				return fmt.Errorf("invalid csv value at row: %v, the struct accept type time with %v", row, unit)
+			}
+			f.Set(reflect.ValueOf(t))
+			return nil
+		default:
+			return fmt.Errorf("tag %v of field %v must be %v or %v, got: %v", tagTime, sf.Name, timeUnix, timeUnixMs, unit)
+		}
+		layout := sf.Tag.Get(tagLayout)
+		if layout == "" {
+			layout = time.RFC3339
+		}
+		t, err := time.Parse(layout, val)
+		if err != nil && vf.xlsx {
+			//date cell of xlsx is RFC3339
+			t, err = time.Parse(time.RFC3339, val)
+		}
+		if err != nil {
+			return fmt.Errorf("invalid csv value at row: %v, the struct accept type time with layout %v", row, layout)
+		}
+		f.Set(reflect.ValueOf(t))
+		return nil
+	}
+	_, isDecimal := f.Interface().(Decimal)
+	if format := sf.Tag.Get(tagNumber); (isNumber(f) || isDecimal) && (format != "" || vf.number != "") {
+		if format == "" {
+			format = vf.number
+		}
+		v, err := parseNumber(val, format)
+		if err != nil {
+			return err
+		}
+		val = v
+	}
+	if format := sf.Tag.Get(tagBool); f.Kind() == reflect.Bool && (format != "" || vf.bool != "") {
+		if format == "" {
+			format = vf.bool
+		}
+		v, err := parseBool(val, format)
+		if err != nil {
+			return err
+		}
+		val = v
+	}
+	if isDecimal {
+		d, err := ParseDecimal(val)
+		if err != nil {
+			return fmt.Errorf("invalid csv value at row: %v, the struct accept type decimal", row)
+		}
+		scale, err := tagValue(sf, sf.Name, tagScale)
+		if err != nil {
+			return err
+		}
+		f.Set(reflect.ValueOf(d.rescale(scale)))
+		return nil
+	}
+	return typeSafe(f, val, row)
+}
+
+func splitTag(sf reflect.StructField) string {
+	if sep := sf.Tag.Get(tagSplit); sep != "" {
+		return sep
+	}
+	return defaultSplit
 }
 
 func typeSafe(f reflect.Value, val string, row int) error {
 	switch f.Interface().(type) {
 	case string:
 		f.SetString(val)
+	case time.Duration:
+		d, err := parseDuration(val)
+		if err != nil {
+			return fmt.Errorf("invalid csv value at row: %v, the struct accept type duration", row)
+		}
+		f.SetInt(int64(d))
 	case bool:
 		b, err := strconv.ParseBool(val)
 		if err != nil {
@@ -150,42 +425,90 @@ func typeSafe(f reflect.Value, val string, row int) error {
 	return nil
 }
 
+// Close stop the reader, it's safe to close before every row is read
 func (c *Executor[T]) Close() {
+	if c.done != nil {
+		close(c.done)
+	}
 	close(c.nextChan)
-	close(c.outChan)
-	close(c.errChan)
 }
 
 func (c *Executor[T]) isValidStruct(size int, fieldSize int) bool {
+	if c.ops.FieldsPerRecord < 0 {
+		//variable fields, the columns over struct field will be ignored
+		return true
+	}
 	//to avoid panic, number of column must less than or equal struct field + skipper
 	return size <= (fieldSize + len(c.ops.skipper))
 }
 
-func (c *Executor[T]) valueSetter(ref T, data []string, row int) error {
+// noOfSkipped return number of skip columns that exist in the row
+func (c *Executor[T]) noOfSkipped(size int) int {
+	if c.ops.FieldsPerRecord >= 0 {
+		return len(c.ops.skipper)
+	}
+	n := 0
+	for k := range c.ops.skipper {
+		if k < size {
+			n++
+		}
+	}
+	return n
+}
+
+func (c *Executor[T]) valueSetter(ref T, data []string, row int) (*T, error) {
 	//skip header if required
 	if c.ops.SkipHeader && row == 0 {
-		return nil
+		return nil, c.setHeader(reflect.TypeOf(ref), data)
 	}
 
-	v := reflect.ValueOf(&ref).Elem()
+	data, err := c.ops.preTransform(data, row)
+	if err != nil {
+		return nil, err
+	}
+
+	noOfField := len(structFields(reflect.TypeOf(ref)))
 	//check if number of csv columns equal struct fields
-	if !c.isValidStruct(len(data), v.NumField()) {
-		return fmt.Errorf("number of column is not match with struct at row: %v, expected: %v, got: %v", row, v.NumField(), realNoOfCol(len(data), len(c.ops.skipper)))
+	if c.columns == nil && !c.isValidStruct(len(data), noOfField) {
+		return nil, rowError(row, "", "", fmt.Errorf("number of column is not match with struct at row: %v, expected: %v, got: %v", row, noOfField, realNoOfCol(len(data), c.noOfSkipped(len(data)))))
 	}
 
 	//set value by using reflex
-	err := c.setValue(data, &ref, row)
+	err = c.setValue(data, &ref, row)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	keep, err := c.postConvert(&ref, row)
+	if !keep {
+		return nil, err
 	}
 
 	//validate struct value from tag
-	err = validateStruct(ref, row)
+	err = validateValue(reflect.ValueOf(&ref).Elem(), row, c.rules)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	c.send(&ref)
+	return &ref, nil
+}
+
+// setHeader map column by header name when some field is tagged with csv name,
+// the error is returned when the column of the tagged field is not found in header
+func (c *Executor[T]) setHeader(t reflect.Type, header []string) error {
+	fields := structFields(t)
+	c.columns = nil
+	if hasNamedField(fields) {
+		c.columns = headerColumns(fields, header)
+	}
+	if c.ops.Schema != nil {
+		c.applySchema(t, header)
+	}
+	if c.columns == nil {
+		return nil
+	}
+	if f, ok := missingColumn(fields, c.columns, c.ops.skipper); ok {
+		return fmt.Errorf("column %v of field %v is not found in header", f.name, f.path)
+	}
 	return nil
 }
 
@@ -9,6 +9,7 @@ import (
 	"reflect"
 	"strconv"
 	"testing"
+	"time"
 )
 
 func Test_realNoOfCol(t *testing.T) {
@@ -128,20 +129,13 @@ func Test_valueSetter(t *testing.T) {
 			c := Executor[Student]{
 				ops:      tc.ops,
 				outsChan: make(chan []Student, 1),
-				outChan:  make(chan Student, 1),
+				outChan:  make(chan rowValue[Student], 1),
 				//endChan:  make(chan bool, 1),
 				nextChan: make(chan bool, 1),
 				errChan:  make(chan error),
 				run:      true,
 			}
-			//channel watching
-			go func() {
-				for c.run {
-					c.Read()
-					c.Next()
-				}
-			}()
-			e := c.valueSetter(tc.ref, tc.data, 0)
+			_, e := c.valueSetter(tc.ref, tc.data, 0)
 			if fmt.Sprintf("%v", tc.expectedE) != fmt.Sprintf("%v", e) {
 				t.Errorf("must:%v, but got: %v", tc.expectedE, e)
 			}
@@ -445,3 +439,440 @@ func deepEqual[T any](expect []T, actual []*T) error {
 	}
 	return nil
 }
+
+func Test_CsvToStruct_readerOptions(t *testing.T) {
+	type Customer struct {
+		Name string
+		Age  int
+	}
+	tt := []struct {
+		name      string
+		ops       *Options
+		content   string
+		expectedR []Customer
+		expectedE error
+	}{
+		{
+			name: "should ignore comment line and leading space",
+			ops: &Options{
+				SkipHeader:       true,
+				Comment:          '#',
+				TrimLeadingSpace: true,
+			},
+			content:   "NAME,AGE\n# this is comment\nSarah, 12\n",
+			expectedR: []Customer{{Name: "Sarah", Age: 12}},
+			expectedE: nil,
+		},
+		{
+			name: "should accept bare quote when LazyQuotes is true",
+			ops: &Options{
+				SkipHeader: true,
+				LazyQuotes: true,
+			},
+			content:   "NAME,AGE\nSa\"rah,12\n",
+			expectedR: []Customer{{Name: "Sa\"rah", Age: 12}},
+			expectedE: nil,
+		},
+		{
+			name: "should return err when quote is invalid and LazyQuotes is false",
+			ops: &Options{
+				SkipHeader: true,
+			},
+			content:   "NAME,AGE\nSa\"rah,12\n",
+			expectedR: nil,
+			expectedE: errors.New("parse error on line 2, column 3: bare \" in non-quoted-field"),
+		},
+		{
+			name: "should accept variable number of fields when FieldsPerRecord is negative",
+			ops: &Options{
+				SkipHeader:      true,
+				SkipCols:        []int{5},
+				FieldsPerRecord: -1,
+			},
+			content:   "NAME,AGE\nSarah,12,extra\nJohn\n",
+			expectedR: []Customer{{Name: "Sarah", Age: 12}, {Name: "John"}},
+			expectedE: nil,
+		},
+		{
+			name: "should return err when number of fields is not match with FieldsPerRecord",
+			ops: &Options{
+				SkipHeader:      true,
+				FieldsPerRecord: 2,
+			},
+			content:   "NAME,AGE\nSarah\n",
+			expectedR: nil,
+			expectedE: errors.New("record on line 2: wrong number of fields"),
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			err := os.WriteFile("./reader_options_test.csv", []byte(tc.content), 0644)
+			if err != nil {
+				panic(err)
+			}
+			c, _ := NewClient[Customer]("./reader_options_test.csv", tc.ops)
+			r, e := c.CsvToStruct()
+			if fmt.Sprintf("%v", tc.expectedE) != fmt.Sprintf("%v", e) {
+				t.Errorf("must:%v, but got: %v", tc.expectedE, e)
+			}
+			err = deepEqual[Customer](tc.expectedR, r)
+			if err != nil {
+				t.Error(err)
+			}
+			_ = os.Remove("./reader_options_test.csv")
+		})
+	}
+}
+
+func Test_CsvToStruct_multiFile(t *testing.T) {
+	type Customer struct {
+		Name string
+		Age  int
+		File string `source:"file"`
+	}
+	tt := []struct {
+		name      string
+		files     map[string]string
+		expectedR []Customer
+		expectedE error
+	}{
+		{
+			name: "should return rows of every file with source file name",
+			files: map[string]string{
+				"./multi_test_1.csv": "NAME,AGE\nSarah,12\n",
+				"./multi_test_2.csv": "NAME,AGE\nJohn,21\n",
+			},
+			expectedR: []Customer{
+				{Name: "Sarah", Age: 12, File: "multi_test_1.csv"},
+				{Name: "John", Age: 21, File: "multi_test_2.csv"},
+			},
+			expectedE: nil,
+		},
+		{
+			name: "should return err with file name when some file is invalid",
+			files: map[string]string{
+				"./multi_test_1.csv": "NAME,AGE\nSarah,12\n",
+				"./multi_test_2.csv": "NAME,AGE\nJohn,x\n",
+			},
+			expectedR: nil,
+			expectedE: &FileError{
+				File: "multi_test_2.csv",
+				Err:  errors.New("invalid csv value at row: 1, the struct accept type int"),
+			},
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			for name, content := range tc.files {
+				err := os.WriteFile(name, []byte(content), 0644)
+				if err != nil {
+					panic(err)
+				}
+			}
+			c, _ := NewMultiClient[Customer]([]string{"./multi_test_*.csv"})
+			r, e := c.CsvToStruct()
+			if fmt.Sprintf("%v", tc.expectedE) != fmt.Sprintf("%v", e) {
+				t.Errorf("must:%v, but got: %v", tc.expectedE, e)
+			}
+			var fe *FileError
+			if tc.expectedE != nil && !errors.As(e, &fe) {
+				t.Errorf("must: FileError, but got: %T", e)
+			}
+			err := deepEqual[Customer](tc.expectedR, r)
+			if err != nil {
+				t.Error(err)
+			}
+			for name := range tc.files {
+				_ = os.Remove(name)
+			}
+		})
+	}
+}
+
+func Test_CsvToStruct_nestedStruct(t *testing.T) {
+	type Address struct {
+		City string `csv:"city" min:"1"`
+		Zip  int    `csv:"zip"`
+	}
+	type BaseRecord struct {
+		ID int `csv:"id"`
+	}
+	type Customer struct {
+		BaseRecord
+		Name string   `csv:"name"`
+		Home Address  `csv:"home_,inline"`
+		Work *Address `csv:"work_,inline"`
+	}
+	tt := []struct {
+		name      string
+		content   string
+		expectedR []Customer
+		expectedE error
+	}{
+		{
+			name:    "should map column to nested and embedded struct by header",
+			content: "name,id,home_city,home_zip,work_city,work_zip,other\nSarah,1,Bangkok,10110,Nonthaburi,11000,x\n",
+			expectedR: []Customer{
+				{
+					BaseRecord: BaseRecord{ID: 1},
+					Name:       "Sarah",
+					Home:       Address{City: "Bangkok", Zip: 10110},
+					Work:       &Address{City: "Nonthaburi", Zip: 11000},
+				},
+			},
+			expectedE: nil,
+		},
+		{
+			name:      "should return err with field path when nested value is invalid",
+			content:   "id,name,home_city,home_zip,work_city,work_zip\n1,Sarah,,10110,Nonthaburi,11000\n",
+			expectedR: nil,
+			expectedE: errors.New("value of Home.City at row 1 is invalid, value length must more than or equal 1, but got: 0"),
+		},
+		{
+			name:      "should return err when column of nested field is not found in header",
+			content:   "id,name,home_city,home_zip\n1,Sarah,Bangkok,10110\n",
+			expectedR: nil,
+			expectedE: errors.New("column work_city of field Work.City is not found in header"),
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			err := os.WriteFile("./nested_test.csv", []byte(tc.content), 0644)
+			if err != nil {
+				panic(err)
+			}
+			c, _ := NewClient[Customer]("./nested_test.csv")
+			r, e := c.CsvToStruct()
+			if fmt.Sprintf("%v", tc.expectedE) != fmt.Sprintf("%v", e) {
+				t.Errorf("must:%v, but got: %v", tc.expectedE, e)
+			}
+			if len(tc.expectedR) != len(r) {
+				t.Fatalf("must:%v, but got: %v", len(tc.expectedR), len(r))
+			}
+			for i := range r {
+				if !reflect.DeepEqual(tc.expectedR[i], *r[i]) {
+					t.Errorf("must:%+v, but got: %+v", tc.expectedR[i], *r[i])
+				}
+			}
+			_ = os.Remove("./nested_test.csv")
+		})
+	}
+}
+
+func Test_setField(t *testing.T) {
+	type Product struct {
+		Tags   []string `split:";"`
+		Sizes  []int    `split:"|"`
+		Prices []float64
+		Attrs  map[string]string `split:";" kv:":"`
+		Stock  map[string]int    `split:";"`
+		Name   string
+	}
+	tt := []struct {
+		name      string
+		field     int
+		val       string
+		expectedR interface{}
+		expectedE error
+	}{
+		{
+			name:      "should split string slice by split tag",
+			field:     0,
+			val:       "tag1;tag2;tag3",
+			expectedR: []string{"tag1", "tag2", "tag3"},
+			expectedE: nil,
+		},
+		{
+			name:      "should convert each element of int slice",
+			field:     1,
+			val:       "38|39|40",
+			expectedR: []int{38, 39, 40},
+			expectedE: nil,
+		},
+		{
+			name:      "should split by comma when split tag is not found",
+			field:     2,
+			val:       "1.5,2",
+			expectedR: []float64{1.5, 2},
+			expectedE: nil,
+		},
+		{
+			name:      "should return err when some element is invalid",
+			field:     1,
+			val:       "38|x",
+			expectedR: []int(nil),
+			expectedE: errors.New("invalid csv value at row: 1, the struct accept type int"),
+		},
+		{
+			name:      "should return nil slice when value is empty",
+			field:     0,
+			val:       "",
+			expectedR: []string(nil),
+			expectedE: nil,
+		},
+		{
+			name:      "should split map by split and kv tag",
+			field:     3,
+			val:       "color:red;size:L",
+			expectedR: map[string]string{"color": "red", "size": "L"},
+			expectedE: nil,
+		},
+		{
+			name:      "should convert map value and use = when kv tag is not found",
+			field:     4,
+			val:       "a=1;b=2",
+			expectedR: map[string]int{"a": 1, "b": 2},
+			expectedE: nil,
+		},
+		{
+			name:      "should return err when map item is not key value pair",
+			field:     4,
+			val:       "a=1;b",
+			expectedR: map[string]int(nil),
+			expectedE: errors.New("invalid csv value at row: 1, b is not a key=value pair"),
+		},
+		{
+			name:      "should set scalar value",
+			field:     5,
+			val:       "shirt",
+			expectedR: "shirt",
+			expectedE: nil,
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			v := reflect.ValueOf(&Product{}).Elem()
+			e := setField(v.Field(tc.field), v.Type().Field(tc.field), tc.val, 1, valueFormat{})
+			if fmt.Sprintf("%v", tc.expectedE) != fmt.Sprintf("%v", e) {
+				t.Errorf("must:%v, but got: %v", tc.expectedE, e)
+			}
+			if !reflect.DeepEqual(tc.expectedR, v.Field(tc.field).Interface()) {
+				t.Errorf("must:%#v, but got: %#v", tc.expectedR, v.Field(tc.field).Interface())
+			}
+		})
+	}
+}
+
+func Test_setField_timeAndPointer(t *testing.T) {
+	type Order struct {
+		CreateAt time.Time  `layout:"2006-01-02"`
+		UpdateAt *time.Time `layout:"02/01/2006"`
+		Amount   *int
+		PaidAt   time.Time
+	}
+	tt := []struct {
+		name      string
+		field     int
+		val       string
+		expectedR interface{}
+		expectedE error
+	}{
+		{
+			name:      "should parse time with layout tag",
+			field:     0,
+			val:       "2022-01-31",
+			expectedR: time.Date(2022, 1, 31, 0, 0, 0, 0, time.UTC),
+			expectedE: nil,
+		},
+		{
+			name:      "should return err when time is not match with layout",
+			field:     0,
+			val:       "31/01/2022",
+			expectedR: time.Time{},
+			expectedE: errors.New("invalid csv value at row: 1, the struct accept type time with layout 2006-01-02"),
+		},
+		{
+			name:      "should parse time with RFC3339 when layout tag is not found",
+			field:     3,
+			val:       "2022-01-31T10:00:00Z",
+			expectedR: time.Date(2022, 1, 31, 10, 0, 0, 0, time.UTC),
+			expectedE: nil,
+		},
+		{
+			name:      "should keep nil when pointer value is empty",
+			field:     2,
+			val:       "",
+			expectedR: (*int)(nil),
+			expectedE: nil,
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			v := reflect.ValueOf(&Order{}).Elem()
+			e := setField(v.Field(tc.field), v.Type().Field(tc.field), tc.val, 1, valueFormat{})
+			if fmt.Sprintf("%v", tc.expectedE) != fmt.Sprintf("%v", e) {
+				t.Errorf("must:%v, but got: %v", tc.expectedE, e)
+			}
+			if !reflect.DeepEqual(tc.expectedR, v.Field(tc.field).Interface()) {
+				t.Errorf("must:%v, but got: %v", tc.expectedR, v.Field(tc.field).Interface())
+			}
+		})
+	}
+
+	t.Run("should set pointer value", func(t *testing.T) {
+		v := reflect.ValueOf(&Order{}).Elem()
+		e := setField(v.Field(1), v.Type().Field(1), "31/01/2022", 1, valueFormat{})
+		if e != nil {
+			t.Errorf("must:nil, but got: %v", e)
+		}
+		r := v.Field(1).Interface().(*time.Time)
+		if r == nil || !r.Equal(time.Date(2022, 1, 31, 0, 0, 0, 0, time.UTC)) {
+			t.Errorf("must:2022-01-31, but got: %v", r)
+		}
+	})
+}
+
+func Test_CsvToRows_order(t *testing.T) {
+	type Item struct {
+		ID   int    `csv:"id"`
+		Name string `csv:"name" max:"3"`
+	}
+	var b []byte
+	b = append(b, "id,name\n"...)
+	for i := 1; i <= 500; i++ {
+		b = append(b, fmt.Sprintf("%v,n\n", i)...)
+	}
+	err := os.WriteFile("./order_test.csv", b, 0644)
+	if err != nil {
+		panic(err)
+	}
+	defer os.Remove("./order_test.csv")
+
+	t.Run("should return rows in the order of the file", func(t *testing.T) {
+		c, _ := NewClient[Item]("./order_test.csv")
+		rows := c.CsvToRows()
+		defer rows.Close()
+		expected := 1
+		for rows.Next() {
+			v, err := rows.Read()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatalf("must:nil, but got: %v", err)
+			}
+			if v.ID != expected || rows.Row() != expected {
+				t.Fatalf("must:%v, but got: %v at row %v", expected, v.ID, rows.Row())
+			}
+			expected++
+		}
+		if expected != 501 {
+			t.Errorf("must:%v, but got: %v", 501, expected)
+		}
+	})
+
+	t.Run("should return error of the first invalid row", func(t *testing.T) {
+		content := append(append([]byte{}, b...), "501,long\n"...)
+		content = append(content[:8], append([]byte("0,long\n0,long2\n"), content[8:]...)...)
+		err := os.WriteFile("./order_test.csv", content, 0644)
+		if err != nil {
+			panic(err)
+		}
+		c, _ := NewClient[Item]("./order_test.csv")
+		_, e := c.CsvToStruct()
+		expected := "value of Name at row 1 is invalid, value length must less than or equal 3, but got: 4"
+		if fmt.Sprintf("%v", e) != expected {
+			t.Errorf("must:%v, but got: %v", expected, e)
+		}
+	})
+}
@@ -0,0 +1,134 @@
+package csvtogo
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+)
+
+const tagScale = "scale"
+
+// Decimal is the exact decimal number such as money, the value is kept as unscaled integer and the number of digits after the point,
+// then 12.30 is written back as 12.30 not 12.3. The zero value is 0
+type Decimal struct {
+	unscaled *big.Int
+	scale    int
+}
+
+// ParseDecimal parse the number in Go syntax such as -1234.50, the exponent is not supported
+func ParseDecimal(s string) (Decimal, error) {
+	digits := s
+	if strings.HasPrefix(digits, "-") || strings.HasPrefix(digits, "+") {
+		digits = digits[1:]
+	}
+	i, f, _ := strings.Cut(digits, ".")
+	if i == "" && f == "" || strings.Trim(i+f, "0123456789") != "" {
+		return Decimal{}, fmt.Errorf("%v is not a decimal number", s)
+	}
+	n, _ := new(big.Int).SetString(i+f, 10)
+	if strings.HasPrefix(s, "-") {
+		n.Neg(n)
+	}
+	return Decimal{unscaled: n, scale: len(f)}, nil
+}
+
+// Scale return the number of digits after the point
+func (d Decimal) Scale() int {
+	return d.scale
+}
+
+// Rat return the value as big.Rat for calculation
+func (d Decimal) Rat() *big.Rat {
+	if d.unscaled == nil {
+		return new(big.Rat)
+	}
+	den := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(d.scale)), nil)
+	return new(big.Rat).SetFrac(d.unscaled, den)
+}
+
+// String return the value with every digit after the point such as 12.30
+func (d Decimal) String() string {
+	if d.unscaled == nil {
+		return "0"
+	}
+	s := new(big.Int).Abs(d.unscaled).String()
+	if d.scale > 0 {
+		if len(s) <= d.scale {
+			s = strings.Repeat("0", d.scale-len(s)+1) + s
+		}
+		s = s[:len(s)-d.scale] + "." + s[len(s)-d.scale:]
+	}
+	if d.unscaled.Sign() < 0 {
+		return "-" + s
+	}
+	return s
+}
+
+// rescale add zero digits after the point until the scale is n, the digits are never removed
+func (d Decimal) rescale(n int) Decimal {
+	if d.unscaled == nil || n <= d.scale {
+		return d
+	}
+	m := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n-d.scale)), nil)
+	return Decimal{unscaled: m.Mul(m, d.unscaled), scale: n}
+}
+
+// MarshalText write the value as String
+func (d Decimal) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// MarshalJSON write the value as JSON number with every digit after the point
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalJSON parse JSON number or string by ParseDecimal
+func (d *Decimal) UnmarshalJSON(b []byte) error {
+	return d.UnmarshalText(bytes.Trim(b, `"`))
+}
+
+// UnmarshalText parse the value by ParseDecimal
+func (d *Decimal) UnmarshalText(b []byte) error {
+	v, err := ParseDecimal(string(b))
+	if err != nil {
+		return err
+	}
+	*d = v
+	return nil
+}
+
+// Value write the value as string to database, the driver convert it to the numeric column without rounding
+func (d Decimal) Value() (driver.Value, error) {
+	return d.String(), nil
+}
+
+func checkScaleField(sf reflect.StructField, name string, fv reflect.Value, row int) error {
+	scale, err := tagValue(sf, name, tagScale)
+	if err != nil {
+		return err
+	}
+	if scale < 0 {
+		//no tag found, then skip validate
+		return nil
+	}
+	if fv.Kind() == reflect.Ptr {
+		if fv.IsNil() {
+			return nil
+		}
+		fv = fv.Elem()
+	}
+	d, ok := fv.Interface().(Decimal)
+	if ok && d.scale > scale {
+		return fmt.Errorf("value of %v at row %v is invalid, scale must less than or equal %v, but got: %v",
+			name,
+			row,
+			scale,
+			d.scale,
+		)
+	}
+	return nil
+}
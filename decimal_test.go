@@ -0,0 +1,152 @@
+package csvtogo
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+)
+
+func Test_ParseDecimal(t *testing.T) {
+	tt := []struct {
+		name      string
+		val       string
+		expectedR string
+		expectedS int
+		expectedE error
+	}{
+		{name: "should keep every digit after the point", val: "1234.50", expectedR: "1234.50", expectedS: 2},
+		{name: "should parse integer", val: "-42", expectedR: "-42", expectedS: 0},
+		{name: "should parse value less than one", val: "-0.05", expectedR: "-0.05", expectedS: 2},
+		{name: "should parse without leading zero", val: ".5", expectedR: "0.5", expectedS: 1},
+		{name: "should parse more digits than float64", val: "12345678901234567890.123456789", expectedR: "12345678901234567890.123456789", expectedS: 9},
+		{name: "should return error when value is not a number", val: "1e3", expectedR: "0", expectedE: errors.New("1e3 is not a decimal number")},
+		{name: "should return error when value is empty", val: "", expectedR: "0", expectedE: errors.New(" is not a decimal number")},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			r, e := ParseDecimal(tc.val)
+			if fmt.Sprintf("%v", tc.expectedE) != fmt.Sprintf("%v", e) {
+				t.Errorf("must:%v, but got: %v", tc.expectedE, e)
+			}
+			if tc.expectedR != r.String() {
+				t.Errorf("must:%v, but got: %v", tc.expectedR, r.String())
+			}
+			if tc.expectedS != r.Scale() {
+				t.Errorf("must:%v, but got: %v", tc.expectedS, r.Scale())
+			}
+		})
+	}
+
+	t.Run("should return exact rat", func(t *testing.T) {
+		d, _ := ParseDecimal("0.10")
+		if d.Rat().String() != "1/10" {
+			t.Errorf("must:%v, but got: %v", "1/10", d.Rat().String())
+		}
+	})
+}
+
+func Test_Decimal(t *testing.T) {
+	type Payment struct {
+		Ref    string   `csv:"ref"`
+		Amount Decimal  `csv:"amount" scale:"2"`
+		Fee    *Decimal `csv:"fee"`
+	}
+	tt := []struct {
+		name      string
+		ops       *Options
+		content   string
+		expectedR string
+		expectedE error
+	}{
+		{
+			name:      "should parse decimal and pad digits to scale",
+			ops:       &Options{SkipHeader: true},
+			content:   "ref,amount,fee\nA,0.1,0.0001\nB,20,\n",
+			expectedR: "[{A 0.10 0.0001} {B 20.00 <nil>}]",
+		},
+		{
+			name:      "should parse decimal by NumberFormat",
+			ops:       &Options{SkipHeader: true, NumberFormat: NumberEU},
+			content:   "ref,amount,fee\nA,\"€1.234,5\",\"1,5%\"\n",
+			expectedR: "[{A 1234.50 0.015}]",
+		},
+		{
+			name:      "should return error when scale is more than tag",
+			ops:       &Options{SkipHeader: true},
+			content:   "ref,amount,fee\nA,0.125,\n",
+			expectedE: errors.New("value of Amount at row 1 is invalid, scale must less than or equal 2, but got: 3"),
+		},
+		{
+			name:      "should return error when value is not a decimal",
+			ops:       &Options{SkipHeader: true},
+			content:   "ref,amount,fee\nA,abc,\n",
+			expectedE: errors.New("invalid csv value at row: 1, the struct accept type decimal"),
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			err := os.WriteFile("./decimal_test.csv", []byte(tc.content), 0644)
+			if err != nil {
+				panic(err)
+			}
+			defer os.Remove("./decimal_test.csv")
+
+			c, _ := NewClient[Payment]("./decimal_test.csv", tc.ops)
+			r, e := c.CsvToStruct()
+			if fmt.Sprintf("%v", tc.expectedE) != fmt.Sprintf("%v", e) {
+				t.Errorf("must:%v, but got: %v", tc.expectedE, e)
+			}
+			if tc.expectedE != nil {
+				return
+			}
+			var s []string
+			for _, v := range r {
+				s = append(s, fmt.Sprintf("{%v %v %v}", v.Ref, v.Amount, v.Fee))
+			}
+			if tc.expectedR != "["+strings.Join(s, " ")+"]" {
+				t.Errorf("must:%v, but got: %v", tc.expectedR, s)
+			}
+		})
+	}
+}
+
+func Test_Decimal_write(t *testing.T) {
+	type Payment struct {
+		Amount Decimal `csv:"amount" db:"amount"`
+	}
+	err := os.WriteFile("./decimal_test.csv", []byte("amount\n12345678901234567890.10\n-0.50\n"), 0644)
+	if err != nil {
+		panic(err)
+	}
+	defer os.Remove("./decimal_test.csv")
+
+	t.Run("should write decimal as numeric literal", func(t *testing.T) {
+		c, _ := NewClient[Payment]("./decimal_test.csv")
+		var b bytes.Buffer
+		e := WriteSQL(&b, "payment", c, &SQLOptions{Dialect: DialectPostgres})
+		if e != nil {
+			t.Errorf("must:nil, but got: %v", e)
+		}
+		expected := "INSERT INTO \"payment\" (\"amount\") VALUES\n(12345678901234567890.10),\n(-0.50);\n"
+		if b.String() != expected {
+			t.Errorf("must:%v, but got: %v", expected, b.String())
+		}
+	})
+
+	t.Run("should marshal and unmarshal JSON without rounding", func(t *testing.T) {
+		d, _ := ParseDecimal("12345678901234567890.10")
+		b, e := json.Marshal(Payment{Amount: d})
+		if e != nil || string(b) != `{"Amount":12345678901234567890.10}` {
+			t.Errorf("must:%v, but got: %v %v", `{"Amount":12345678901234567890.10}`, string(b), e)
+		}
+		var p Payment
+		e = json.Unmarshal(b, &p)
+		if e != nil || p.Amount.String() != "12345678901234567890.10" {
+			t.Errorf("must:%v, but got: %v %v", "12345678901234567890.10", p.Amount, e)
+		}
+	})
+}
@@ -0,0 +1,61 @@
+package csvtogo
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	tagTime = "time"
+
+	timeUnix   = "unix"   //epoch seconds
+	timeUnixMs = "unixms" //epoch milliseconds
+)
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// parseDuration parse Go syntax such as 1h2m or HH:MM:SS such as 00:05:30, the hours can be more than 24
+// and the seconds can have fraction such as 00:00:01.5
+func parseDuration(val string) (time.Duration, error) {
+	if !strings.Contains(val, ":") {
+		return time.ParseDuration(val)
+	}
+	neg := strings.HasPrefix(val, "-")
+	s := strings.TrimPrefix(val, "-")
+	parts := strings.Split(s, ":")
+	if len(parts) != 3 {
+		return 0, fmt.Errorf("%v is not HH:MM:SS", val)
+	}
+	h, err := strconv.ParseUint(parts[0], 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("%v is not HH:MM:SS", val)
+	}
+	m, err := strconv.ParseUint(parts[1], 10, 8)
+	if err != nil || m >= 60 || len(parts[1]) != 2 {
+		return 0, fmt.Errorf("%v is not HH:MM:SS", val)
+	}
+	sec, err := time.ParseDuration(parts[2] + "s")
+	if err != nil || sec < 0 || sec >= time.Minute || len(parts[2]) < 2 || parts[2][0] == '+' {
+		return 0, fmt.Errorf("%v is not HH:MM:SS", val)
+	}
+	d := time.Duration(h)*time.Hour + time.Duration(m)*time.Minute + sec
+	if neg {
+		return -d, nil
+	}
+	return d, nil
+}
+
+// parseUnix parse the epoch seconds or milliseconds by time tag, the time is UTC
+func parseUnix(val, unit string) (time.Time, error) {
+	n, err := strconv.ParseInt(val, 10, 64)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if unit == timeUnixMs {
+		return time.UnixMilli(n).UTC(), nil
+	}
+	return time.Unix(n, 0).UTC(), nil
+}
@@ -0,0 +1,134 @@
+package csvtogo
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_parseDuration(t *testing.T) {
+	tt := []struct {
+		name      string
+		val       string
+		expectedR time.Duration
+		expectedE error
+	}{
+		{name: "should parse go syntax", val: "1h2m", expectedR: time.Hour + 2*time.Minute},
+		{name: "should parse HH:MM:SS", val: "00:05:30", expectedR: 5*time.Minute + 30*time.Second},
+		{name: "should parse hours more than 24", val: "36:00:00", expectedR: 36 * time.Hour},
+		{name: "should parse fraction of seconds", val: "00:00:01.5", expectedR: 1500 * time.Millisecond},
+		{name: "should parse negative HH:MM:SS", val: "-01:00:00", expectedR: -time.Hour},
+		{name: "should return error when minutes is more than 59", val: "00:60:00", expectedE: errors.New("00:60:00 is not HH:MM:SS")},
+		{name: "should return error when seconds is missing", val: "05:30", expectedE: errors.New("05:30 is not HH:MM:SS")},
+		{name: "should return error when unit is missing", val: "90", expectedE: errors.New(`time: missing unit in duration "90"`)},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			r, e := parseDuration(tc.val)
+			if fmt.Sprintf("%v", tc.expectedE) != fmt.Sprintf("%v", e) {
+				t.Errorf("must:%v, but got: %v", tc.expectedE, e)
+			}
+			if tc.expectedR != r {
+				t.Errorf("must:%v, but got: %v", tc.expectedR, r)
+			}
+		})
+	}
+}
+
+func Test_typeSafe_duration(t *testing.T) {
+	t.Run("should set duration", func(t *testing.T) {
+		var d time.Duration
+		e := typeSafe(reflect.ValueOf(&d).Elem(), "1m30s", 0)
+		if e != nil || d != 90*time.Second {
+			t.Errorf("must:%v, but got: %v %v", 90*time.Second, d, e)
+		}
+	})
+
+	t.Run("should return err when value is not duration", func(t *testing.T) {
+		var d time.Duration
+		e := typeSafe(reflect.ValueOf(&d).Elem(), "abc", 1)
+		expected := "invalid csv value at row: 1, the struct accept type duration"
+		if fmt.Sprintf("%v", e) != expected {
+			t.Errorf("must:%v, but got: %v", expected, e)
+		}
+	})
+}
+
+func Test_Duration(t *testing.T) {
+	type Call struct {
+		Number   string         `csv:"number"`
+		Duration time.Duration  `csv:"duration"`
+		Wait     *time.Duration `csv:"wait"`
+		Start    time.Time      `csv:"start" time:"unix"`
+		End      time.Time      `csv:"end" time:"unixms"`
+	}
+	type Invalid struct {
+		Start time.Time `csv:"start" time:"epoch"`
+	}
+	tt := []struct {
+		name      string
+		read      func(string) ([]string, error)
+		content   string
+		expectedR string
+		expectedE error
+	}{
+		{
+			name: "should parse duration and unix time",
+			read: func(file string) ([]string, error) {
+				c, _ := NewClient[Call](file, &Options{SkipHeader: true, NumberFormat: NumberEU})
+				r, err := c.CsvToStruct()
+				var s []string
+				for _, v := range r {
+					s = append(s, fmt.Sprintf("{%v %v %v %v %v}", v.Number, v.Duration, v.Wait, v.Start.Format(time.RFC3339), v.End.Format(time.RFC3339Nano)))
+				}
+				return s, err
+			},
+			content:   "number,duration,wait,start,end\n0812345678,1.5h,,1700000000,1700000000250\n",
+			expectedR: "[{0812345678 1h30m0s <nil> 2023-11-14T22:13:20Z 2023-11-14T22:13:20.25Z}]",
+		},
+		{
+			name: "should return error when unix time is invalid",
+			read: func(file string) ([]string, error) {
+				c, _ := NewClient[Call](file)
+				_, err := c.CsvToStruct()
+				return nil, err
+			},
+			content:   "number,duration,wait,start,end\n1,00:05:30,00:00:10,2023-11-14,0\n",
+			expectedE: errors.New("invalid csv value at row: 1, the struct accept type time with unix"),
+		},
+		{
+			name: "should return error when time tag is not support",
+			read: func(file string) ([]string, error) {
+				c, _ := NewClient[Invalid](file)
+				_, err := c.CsvToStruct()
+				return nil, err
+			},
+			content:   "start\n1700000000\n",
+			expectedE: errors.New("tag time of field Start must be unix or unixms, got: epoch"),
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			err := os.WriteFile("./duration_test.csv", []byte(tc.content), 0644)
+			if err != nil {
+				panic(err)
+			}
+			defer os.Remove("./duration_test.csv")
+
+			r, e := tc.read("./duration_test.csv")
+			if fmt.Sprintf("%v", tc.expectedE) != fmt.Sprintf("%v", e) {
+				t.Errorf("must:%v, but got: %v", tc.expectedE, e)
+			}
+			if tc.expectedE != nil {
+				return
+			}
+			if tc.expectedR != "["+strings.Join(r, " ")+"]" {
+				t.Errorf("must:%v, but got: %v", tc.expectedR, r)
+			}
+		})
+	}
+}
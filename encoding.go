@@ -0,0 +1,223 @@
+package csvtogo
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+const (
+	EncodingAuto        = "auto"
+	EncodingUTF8        = "utf-8"
+	EncodingUTF16LE     = "utf-16le"
+	EncodingUTF16BE     = "utf-16be"
+	EncodingWindows874  = "windows-874"
+	EncodingTIS620      = "tis-620"
+	EncodingLatin1      = "iso-8859-1"
+	EncodingWindows1252 = "windows-1252"
+)
+
+var (
+	bomUTF8    = []byte{0xEF, 0xBB, 0xBF}
+	bomUTF16LE = []byte{0xFF, 0xFE}
+	bomUTF16BE = []byte{0xFE, 0xFF}
+)
+
+// encodingAlias map common name of encoding to the name that csvtogo used
+var encodingAlias = map[string]string{
+	"":             EncodingAuto,
+	"auto":         EncodingAuto,
+	"utf8":         EncodingUTF8,
+	"utf-8":        EncodingUTF8,
+	"utf16le":      EncodingUTF16LE,
+	"utf-16le":     EncodingUTF16LE,
+	"utf16be":      EncodingUTF16BE,
+	"utf-16be":     EncodingUTF16BE,
+	"windows-874":  EncodingWindows874,
+	"cp874":        EncodingWindows874,
+	"tis-620":      EncodingTIS620,
+	"tis620":       EncodingTIS620,
+	"iso-8859-11":  EncodingTIS620,
+	"iso-8859-1":   EncodingLatin1,
+	"latin1":       EncodingLatin1,
+	"latin-1":      EncodingLatin1,
+	"windows-1252": EncodingWindows1252,
+	"cp1252":       EncodingWindows1252,
+}
+
+func normalizeEncoding(enc string) (string, error) {
+	if v, ok := encodingAlias[strings.ToLower(strings.TrimSpace(enc))]; ok {
+		return v, nil
+	}
+	return "", fmt.Errorf("csvtogo is not support encoding %v", enc)
+}
+
+// newDecoder wrap r with a reader that always return UTF-8 content without BOM
+func newDecoder(r io.Reader, enc string) (io.Reader, error) {
+	enc, err := normalizeEncoding(enc)
+	if err != nil {
+		return nil, err
+	}
+
+	br := bufio.NewReader(r)
+	//BOM always win, no matter which encoding is set
+	head, _ := br.Peek(3)
+	switch {
+	case bytes.HasPrefix(head, bomUTF8):
+		_, _ = br.Discard(len(bomUTF8))
+		return br, nil
+	case bytes.HasPrefix(head, bomUTF16LE):
+		_, _ = br.Discard(len(bomUTF16LE))
+		enc = EncodingUTF16LE
+	case bytes.HasPrefix(head, bomUTF16BE):
+		_, _ = br.Discard(len(bomUTF16BE))
+		enc = EncodingUTF16BE
+	}
+
+	switch enc {
+	case EncodingUTF16LE:
+		return &transcoder{next: utf16Decoder(br, false)}, nil
+	case EncodingUTF16BE:
+		return &transcoder{next: utf16Decoder(br, true)}, nil
+	case EncodingWindows874, EncodingTIS620:
+		return &transcoder{next: singleByteDecoder(br, windows874)}, nil
+	case EncodingLatin1:
+		return &transcoder{next: singleByteDecoder(br, latin1)}, nil
+	case EncodingWindows1252:
+		return &transcoder{next: singleByteDecoder(br, windows1252)}, nil
+	}
+	//auto and utf-8
+	return br, nil
+}
+
+// transcoder convert rune from next to UTF-8 bytes
+type transcoder struct {
+	next func() (rune, error)
+	tmp  [utf8.UTFMax]byte
+	buf  []byte
+}
+
+func (t *transcoder) Read(p []byte) (int, error) {
+	n := 0
+	for n < len(p) {
+		if len(t.buf) > 0 {
+			c := copy(p[n:], t.buf)
+			t.buf = t.buf[c:]
+			n += c
+			continue
+		}
+		r, err := t.next()
+		if err != nil {
+			if n > 0 && err == io.EOF {
+				return n, nil
+			}
+			return n, err
+		}
+		l := utf8.EncodeRune(t.tmp[:], r)
+		t.buf = t.tmp[:l]
+	}
+	return n, nil
+}
+
+func singleByteDecoder(br *bufio.Reader, table func(byte) rune) func() (rune, error) {
+	return func() (rune, error) {
+		b, err := br.ReadByte()
+		if err != nil {
+			return 0, err
+		}
+		return table(b), nil
+	}
+}
+
+func utf16Decoder(br *bufio.Reader, bigEndian bool) func() (rune, error) {
+	unit := func() (uint16, error) {
+		var b [2]byte
+		_, err := io.ReadFull(br, b[:])
+		if err == io.ErrUnexpectedEOF {
+			return 0, fmt.Errorf("invalid utf-16 content, odd number of bytes")
+		}
+		if err != nil {
+			return 0, err
+		}
+		if bigEndian {
+			return uint16(b[0])<<8 | uint16(b[1]), nil
+		}
+		return uint16(b[1])<<8 | uint16(b[0]), nil
+	}
+	//the unit after a high surrogate that is not a low surrogate, it's decoded at the next call
+	var next uint16
+	held := false
+	return func() (rune, error) {
+		u1 := next
+		if held {
+			held = false
+		} else {
+			var err error
+			u1, err = unit()
+			if err != nil {
+				return 0, err
+			}
+		}
+		if !utf16.IsSurrogate(rune(u1)) {
+			return rune(u1), nil
+		}
+		if u1 >= 0xDC00 {
+			//low surrogate without high surrogate
+			return utf8.RuneError, nil
+		}
+		u2, err := unit()
+		if err == io.EOF {
+			return utf8.RuneError, nil
+		}
+		if err != nil {
+			return 0, err
+		}
+		r := utf16.DecodeRune(rune(u1), rune(u2))
+		if r == utf8.RuneError {
+			//keep u2 such as the delimiter
+			next, held = u2, true
+		}
+		return r, nil
+	}
+}
+
+func latin1(b byte) rune {
+	return rune(b)
+}
+
+// cp1252 is the characters of Windows-1252 from 0x80 to 0x9F, the undefined bytes are kept as C1 control like WHATWG
+var cp1252 = [32]rune{
+	'€', '\u0081', '‚', 'ƒ', '„', '…', '†', '‡', 'ˆ', '‰', 'Š', '‹', 'Œ', '\u008D', 'Ž', '\u008F',
+	'\u0090', '‘', '’', '“', '”', '•', '–', '—', '˜', '™', 'š', '›', 'œ', '\u009D', 'ž', 'Ÿ',
+}
+
+// windows1252 is Latin-1 except 0x80 to 0x9F
+func windows1252(b byte) rune {
+	if b >= 0x80 && b <= 0x9F {
+		return cp1252[b-0x80]
+	}
+	return rune(b)
+}
+
+// windows874 is superset of TIS-620, the thai characters start from 0xA1 (U+0E01)
+func windows874(b byte) rune {
+	switch {
+	case b < 0x80:
+		return rune(b)
+	case b == 0x80:
+		return '€'
+	case b == 0x85:
+		return '…'
+	case b >= 0x91 && b <= 0x97:
+		return []rune{'‘', '’', '“', '”', '•', '–', '—'}[b-0x91]
+	case b == 0xA0:
+		return ' '
+	case b >= 0xA1 && b <= 0xDA, b >= 0xDF && b <= 0xFB:
+		return rune(b) - 0xA0 + 0x0E00
+	}
+	return utf8.RuneError
+}
@@ -0,0 +1,142 @@
+package csvtogo
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"testing"
+)
+
+func Test_newDecoder(t *testing.T) {
+	tt := []struct {
+		name      string
+		enc       string
+		input     []byte
+		expectedR string
+		expectedE error
+	}{
+		{
+			name:      "should return same content when encoding is empty and no BOM",
+			enc:       "",
+			input:     []byte("ID,NAME"),
+			expectedR: "ID,NAME",
+			expectedE: nil,
+		},
+		{
+			name:      "should remove UTF-8 BOM",
+			enc:       "",
+			input:     append([]byte{0xEF, 0xBB, 0xBF}, []byte("ID,NAME")...),
+			expectedR: "ID,NAME",
+			expectedE: nil,
+		},
+		{
+			name:      "should transcode UTF-16LE when BOM is found",
+			enc:       EncodingUTF8,
+			input:     []byte{0xFF, 0xFE, 'I', 0, 'D', 0, ',', 0, 0x01, 0x0E},
+			expectedR: "ID,ก",
+			expectedE: nil,
+		},
+		{
+			name:      "should transcode UTF-16BE when BOM is found",
+			enc:       "",
+			input:     []byte{0xFE, 0xFF, 0, 'I', 0, 'D', 0xD8, 0x3D, 0xDE, 0x00},
+			expectedR: "ID😀",
+			expectedE: nil,
+		},
+		{
+			name:      "should keep the unit after unpaired high surrogate",
+			enc:       "",
+			input:     []byte{0xFF, 0xFE, 'a', 0, 0x00, 0xD8, ',', 0, 'b', 0},
+			expectedR: "a\uFFFD,b",
+			expectedE: nil,
+		},
+		{
+			name:      "should replace unpaired low surrogate and high surrogate at the end",
+			enc:       "",
+			input:     []byte{0xFE, 0xFF, 0xDC, 0x00, 0, 'a', 0xD8, 0x00},
+			expectedR: "\uFFFDa\uFFFD",
+			expectedE: nil,
+		},
+		{
+			name:      "should transcode TIS-620 to UTF-8",
+			enc:       EncodingTIS620,
+			input:     []byte{'1', ',', 0xA1, 0xD2, 0xC3},
+			expectedR: "1,การ",
+			expectedE: nil,
+		},
+		{
+			name:      "should transcode Windows-874 to UTF-8",
+			enc:       "cp874",
+			input:     []byte{0x80, 0xDF},
+			expectedR: "€฿",
+			expectedE: nil,
+		},
+		{
+			name:      "should transcode Latin-1 to UTF-8",
+			enc:       "latin1",
+			input:     []byte{'c', 'a', 'f', 0xE9},
+			expectedR: "café",
+			expectedE: nil,
+		},
+		{
+			name:      "should transcode Windows-1252 to UTF-8",
+			enc:       "windows-1252",
+			input:     []byte{0x80, 0x93, 'c', 'a', 'f', 0xE9, 0x94, 0x8A, 0x9F},
+			expectedR: "€“café”ŠŸ",
+			expectedE: nil,
+		},
+		{
+			name:      "should return err when encoding is not support",
+			enc:       "ebcdic",
+			input:     []byte("ID"),
+			expectedR: "",
+			expectedE: errors.New("csvtogo is not support encoding ebcdic"),
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			r, e := newDecoder(strings.NewReader(string(tc.input)), tc.enc)
+			if fmt.Sprintf("%v", tc.expectedE) != fmt.Sprintf("%v", e) {
+				t.Errorf("must:%v, but got: %v", tc.expectedE, e)
+			}
+			if r == nil {
+				return
+			}
+			b, err := io.ReadAll(r)
+			if err != nil {
+				t.Errorf("must:nil, but got: %v", err)
+			}
+			if tc.expectedR != string(b) {
+				t.Errorf("must:%v, but got: %v", tc.expectedR, string(b))
+			}
+		})
+	}
+}
+
+func Test_CsvToStruct_encoding(t *testing.T) {
+	type Customer struct {
+		Name string
+		City string
+	}
+	content := []byte{0xFF, 0xFE}
+	for _, r := range "NAME,CITY\nJohn,กรุงเทพ\n" {
+		content = append(content, byte(r), byte(r>>8))
+	}
+	err := os.WriteFile("./encoding_test.csv", content, 0644)
+	if err != nil {
+		panic(err)
+	}
+	defer os.Remove("./encoding_test.csv")
+
+	c, _ := NewClient[Customer]("./encoding_test.csv")
+	r, e := c.CsvToStruct()
+	if e != nil {
+		t.Errorf("must:nil, but got: %v", e)
+	}
+	err = deepEqual[Customer]([]Customer{{Name: "John", City: "กรุงเทพ"}}, r)
+	if err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,270 @@
+package csvtogo
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	tagSource = "source"
+	tagCsv    = "csv"
+	tagSplit  = "split"
+	tagKv     = "kv"
+	tagLayout = "layout"
+	tagTrim   = "trim"
+	tagCase   = "case"
+	tagStrip  = "strip"
+
+	sourceFile   = "file"
+	defaultSplit = ","
+	defaultKv    = "="
+)
+
+// fieldCache keep []fieldBinding of each struct type, the reflection is done only once per type
+var fieldCache sync.Map
+
+// leafTypes are struct types that convert from a single column instead of flatten
+var leafTypes = map[reflect.Type]bool{
+	reflect.TypeOf(time.Time{}): true,
+	reflect.TypeOf(Decimal{}):   true,
+}
+
+// fieldBinding is the struct field that map to csv column, nested struct is flattened
+type fieldBinding struct {
+	index []int
+	name  string //header name, prefix + csv tag name or field name
+	path  string //Go field path used in error message such as Address.City
+	named bool   //csv tag name is set on this field or one of its parent
+	field reflect.StructField
+}
+
+// structType is the flattened fields of struct type in fieldCache, err is not nil when the type can't be flattened
+type structType struct {
+	fields []fieldBinding
+	err    error
+}
+
+// structFields return the flattened fields of t order by column
+func structFields(t reflect.Type) []fieldBinding {
+	return flattenType(t).fields
+}
+
+// validStruct return the error when t can't be flattened such as self-referential struct
+func validStruct(t reflect.Type) error {
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+	return flattenType(t).err
+}
+
+func flattenType(t reflect.Type) structType {
+	if f, ok := fieldCache.Load(t); ok {
+		return f.(structType)
+	}
+	fields, err := flatten(t, nil, "", "", false, map[reflect.Type]bool{t: true})
+	st := structType{fields: fields, err: err}
+	fieldCache.Store(t, st)
+	return st
+}
+
+// flatten return the fields of t and its nested structs, seen is the struct types on the current path,
+// the type that repeat on the path is not flattened and the error is returned
+func flatten(t reflect.Type, index []int, prefix, path string, named bool, seen map[reflect.Type]bool) ([]fieldBinding, error) {
+	var fields []fieldBinding
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if _, ok := sf.Tag.Lookup(tagSource); ok {
+			//filled by csvtogo, not from csv column
+			continue
+		}
+		name, _ := parseCsvTag(sf.Tag.Get(tagCsv))
+		if name == "-" {
+			continue
+		}
+		ft := sf.Type
+		if ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+		isStruct := ft.Kind() == reflect.Struct && !leafTypes[ft]
+		if !sf.IsExported() && !(sf.Anonymous && isStruct && sf.Type.Kind() != reflect.Ptr) {
+			//unexported embedded struct is fine, the exported fields are still settable
+			continue
+		}
+
+		idx := append(append([]int{}, index...), i)
+		if isStruct {
+			if seen[ft] {
+				return fields, fmt.Errorf("csvtogo is not support recursive type %v at field %v", ft.String(), path+sf.Name)
+			}
+			//csv tag name of struct field is the prefix of its fields
+			subPath := path + sf.Name + "."
+			if sf.Anonymous {
+				subPath = path
+			}
+			seen[ft] = true
+			sub, err := flatten(ft, idx, prefix+name, subPath, named || name != "", seen)
+			delete(seen, ft)
+			fields = append(fields, sub...)
+			if err != nil {
+				return fields, err
+			}
+			continue
+		}
+
+		b := fieldBinding{
+			index: idx,
+			name:  prefix + name,
+			path:  path + sf.Name,
+			named: named || name != "",
+			field: sf,
+		}
+		if name == "" {
+			b.name = prefix + sf.Name
+		}
+		fields = append(fields, b)
+	}
+	return fields, nil
+}
+
+// parseCsvTag split `csv:"name,inline"` into name and options
+func parseCsvTag(tag string) (string, []string) {
+	if tag == "" {
+		return "", nil
+	}
+	s := strings.Split(tag, ",")
+	return s[0], s[1:]
+}
+
+// fieldByIndex same as reflect.Value.FieldByIndex but allocate nil pointer of nested struct
+func fieldByIndex(v reflect.Value, index []int) reflect.Value {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v
+}
+
+// lookupField return the field by index without allocating, ok is false when some parent is nil
+func lookupField(v reflect.Value, index []int) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v, true
+}
+
+// hasNamedField return true if some field is tagged with csv name, then the column is mapped by header
+func hasNamedField(fields []fieldBinding) bool {
+	for _, f := range fields {
+		if f.named {
+			return true
+		}
+	}
+	return false
+}
+
+// headerColumns map each header column to index of fields, -1 if no field match with the column
+func headerColumns(fields []fieldBinding, header []string) []int {
+	m := make(map[string]int)
+	for i, f := range fields {
+		m[strings.ToLower(f.name)] = i
+	}
+	columns := make([]int, len(header))
+	for i, h := range header {
+		columns[i] = -1
+		if idx, ok := m[strings.ToLower(strings.TrimSpace(h))]; ok {
+			columns[i] = idx
+		}
+	}
+	return columns
+}
+
+// missingColumn return the field that tagged with csv name but no column is mapped to it, ok is false if every named field is mapped.
+// The column in skipper is not mapped
+func missingColumn(fields []fieldBinding, columns []int, skipper map[int]int) (fieldBinding, bool) {
+	mapped := make(map[int]bool)
+	for i, idx := range columns {
+		if _, ok := skipper[i]; !ok && idx >= 0 {
+			mapped[idx] = true
+		}
+	}
+	for i, f := range fields {
+		if f.named && !mapped[i] {
+			return f, true
+		}
+	}
+	return fieldBinding{}, false
+}
+
+// setSource set file name to the string field that tagged with `source:"file"`
+func setSource[T any](ref *T, file string) {
+	v := reflect.ValueOf(ref).Elem()
+	if v.Kind() != reflect.Struct {
+		return
+	}
+	for i := 0; i < v.NumField(); i++ {
+		if v.Type().Field(i).Tag.Get(tagSource) == sourceFile && v.Field(i).Kind() == reflect.String {
+			v.Field(i).SetString(file)
+		}
+	}
+}
+
+// trimItem trim the element of slice or map, key and value of map, like normalize trim the cell,
+// trim is the default of trim tag such as Options.TrimSpace
+func trimItem(sf reflect.StructField, val string, trim bool) string {
+	if tag := sf.Tag.Get(tagTrim); tag != "" {
+		//the invalid tag is already returned by normalize
+		trim, _ = strconv.ParseBool(tag)
+	}
+	if trim {
+		return strings.TrimSpace(val)
+	}
+	return val
+}
+
+// normalize apply strip, trim and case tags of the field to the value before conversion,
+// trim is the default of trim tag such as Options.TrimSpace
+func normalize(sf reflect.StructField, name, val string, trim bool) (string, error) {
+	if chars := sf.Tag.Get(tagStrip); chars != "" {
+		val = strings.Map(func(r rune) rune {
+			if strings.ContainsRune(chars, r) {
+				return -1
+			}
+			return r
+		}, val)
+	}
+	if tag := sf.Tag.Get(tagTrim); tag != "" {
+		var err error
+		trim, err = strconv.ParseBool(tag)
+		if err != nil {
+			return "", fmt.Errorf("tag %v of field %v must be true or false, got: %v", tagTrim, name, tag)
+		}
+	}
+	if trim {
+		val = strings.TrimSpace(val)
+	}
+	switch tag := sf.Tag.Get(tagCase); tag {
+	case "":
+	case "upper":
+		val = strings.ToUpper(val)
+	case "lower":
+		val = strings.ToLower(val)
+	default:
+		return "", fmt.Errorf("tag %v of field %v must be upper or lower, got: %v", tagCase, name, tag)
+	}
+	return val, nil
+}
@@ -0,0 +1,174 @@
+package csvtogo
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_structFields(t *testing.T) {
+	type Address struct {
+		City string `csv:"city"`
+		Zip  string `csv:"zip"`
+	}
+	type base struct {
+		ID int `csv:"id"`
+	}
+	type Customer struct {
+		base
+		Name     string   `csv:"name"`
+		Home     Address  `csv:"home_,inline"`
+		Work     *Address `csv:"work_,inline"`
+		CreateAt time.Time
+		Ignore   string `csv:"-"`
+		File     string `source:"file"`
+		private  string
+	}
+	type Plain struct {
+		Name string
+		Addr struct {
+			City string
+		}
+	}
+	tt := []struct {
+		name          string
+		t             reflect.Type
+		expectedNames []string
+		expectedPaths []string
+		expectedNamed bool
+	}{
+		{
+			name:          "should flatten embedded and nested struct with prefix",
+			t:             reflect.TypeOf(Customer{}),
+			expectedNames: []string{"id", "name", "home_city", "home_zip", "work_city", "work_zip", "CreateAt"},
+			expectedPaths: []string{"ID", "Name", "Home.City", "Home.Zip", "Work.City", "Work.Zip", "CreateAt"},
+			expectedNamed: true,
+		},
+		{
+			name:          "should flatten nested struct without csv tag",
+			t:             reflect.TypeOf(Plain{}),
+			expectedNames: []string{"Name", "City"},
+			expectedPaths: []string{"Name", "Addr.City"},
+			expectedNamed: false,
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			r := structFields(tc.t)
+			var names, paths []string
+			for _, f := range r {
+				names = append(names, f.name)
+				paths = append(paths, f.path)
+			}
+			if fmt.Sprintf("%v", tc.expectedNames) != fmt.Sprintf("%v", names) {
+				t.Errorf("must:%v, but got: %v", tc.expectedNames, names)
+			}
+			if fmt.Sprintf("%v", tc.expectedPaths) != fmt.Sprintf("%v", paths) {
+				t.Errorf("must:%v, but got: %v", tc.expectedPaths, paths)
+			}
+			if tc.expectedNamed != hasNamedField(r) {
+				t.Errorf("must:%v, but got: %v", tc.expectedNamed, hasNamedField(r))
+			}
+		})
+	}
+}
+
+func Test_validStruct(t *testing.T) {
+	type Node struct {
+		Name string
+		Next *Node
+	}
+	type Child struct {
+		Name   string
+		Parent *struct {
+			Name string
+			Kids []Child
+		}
+	}
+	type Address struct {
+		City string
+	}
+	type Tree struct {
+		Name  string
+		Left  *Tree
+		Right *Tree
+	}
+	type Order struct {
+		Home Address `csv:"home_"`
+		Work Address `csv:"work_"`
+	}
+	tt := []struct {
+		name      string
+		t         reflect.Type
+		expectedE error
+	}{
+		{
+			name:      "should return err when struct refer to itself",
+			t:         reflect.TypeOf(Node{}),
+			expectedE: fmt.Errorf("csvtogo is not support recursive type csvtogo.Node at field Next"),
+		},
+		{
+			name:      "should return err when nested struct refer to its parent",
+			t:         reflect.TypeOf(Tree{}),
+			expectedE: fmt.Errorf("csvtogo is not support recursive type csvtogo.Tree at field Left"),
+		},
+		{
+			name:      "should accept same struct in sibling fields",
+			t:         reflect.TypeOf(Order{}),
+			expectedE: nil,
+		},
+		{
+			name:      "should accept slice of struct that is not flattened",
+			t:         reflect.TypeOf(Child{}),
+			expectedE: nil,
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			e := validStruct(tc.t)
+			if fmt.Sprintf("%v", tc.expectedE) != fmt.Sprintf("%v", e) {
+				t.Errorf("must:%v, but got: %v", tc.expectedE, e)
+			}
+		})
+	}
+
+	t.Run("should return err from NewClientReader when struct refer to itself", func(t *testing.T) {
+		_, e := NewClientReader[Node](strings.NewReader("Name\nA\n"))
+		if fmt.Sprintf("%v", e) != "csvtogo is not support recursive type csvtogo.Node at field Next" {
+			t.Errorf("must:%v, but got: %v", "csvtogo is not support recursive type csvtogo.Node at field Next", e)
+		}
+	})
+}
+
+func Test_headerColumns(t *testing.T) {
+	type Customer struct {
+		Name string `csv:"name"`
+		Age  int    `csv:"age"`
+	}
+	tt := []struct {
+		name      string
+		header    []string
+		expectedR []int
+	}{
+		{
+			name:      "should map column by header name case insensitive",
+			header:    []string{"AGE", " Name "},
+			expectedR: []int{1, 0},
+		},
+		{
+			name:      "should return -1 when header is not match with any field",
+			header:    []string{"ID", "name"},
+			expectedR: []int{-1, 0},
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			r := headerColumns(structFields(reflect.TypeOf(Customer{})), tc.header)
+			if fmt.Sprintf("%v", tc.expectedR) != fmt.Sprintf("%v", r) {
+				t.Errorf("must:%v, but got: %v", tc.expectedR, r)
+			}
+		})
+	}
+}
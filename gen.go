@@ -0,0 +1,163 @@
+package csvtogo
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// initialisms are kept upper case in field name such as ID, URL
+var initialisms = map[string]bool{
+	"ID":   true,
+	"URL":  true,
+	"API":  true,
+	"UUID": true,
+	"IP":   true,
+	"HTTP": true,
+	"SKU":  true,
+}
+
+// GenerateStruct read every row of file, infer the type of each column and return the Go struct named name
+// with csv, min/max and layout tags that ready to use with NewClient[T].
+func GenerateStruct(file, name string, ops ...*Options) (string, error) {
+	header, types, _, err := inferColumns(file, ops...)
+	if err != nil {
+		return "", err
+	}
+	withHeader := len(ops) == 0 || ops[0].SkipHeader
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "type %v struct {\n", name)
+	used := make(map[string]bool)
+	for i, h := range header {
+		field := goFieldName(h, i)
+		for n := 2; used[field]; n++ {
+			field = goFieldName(h, i) + strconv.Itoa(n)
+		}
+		used[field] = true
+
+		t := types[i]
+		var tags []string
+		if withHeader {
+			tags = append(tags, fmt.Sprintf(`csv:%q`, h))
+		}
+		goType := ""
+		switch t.kind() {
+		case TypeInt:
+			goType = "int"
+		case TypeFloat:
+			goType = "float64"
+		case TypeBool:
+			goType = "bool"
+		case TypeTime:
+			goType = "time.Time"
+			tags = append(tags, fmt.Sprintf(`layout:%q`, t.layout()))
+		default:
+			goType = "string"
+			if !t.nullable() && t.minLen > 0 {
+				tags = append(tags, `min:"1"`)
+			}
+			tags = append(tags, fmt.Sprintf(`max:"%v"`, t.maxLen))
+		}
+		if t.nullable() && goType != "string" {
+			goType = "*" + goType
+		}
+		if len(tags) == 0 {
+			fmt.Fprintf(&b, "\t%v %v\n", field, goType)
+			continue
+		}
+		fmt.Fprintf(&b, "\t%v %v `%v`\n", field, goType, strings.Join(tags, " "))
+	}
+	b.WriteString("}\n")
+
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		return "", err
+	}
+	return string(src), nil
+}
+
+// inferColumns stream every row of file and infer the type of each column, n is number of rows
+func inferColumns(file string, ops ...*Options) (header []string, types []*columnType, n int, err error) {
+	c, err := NewDynamicClient(file, ops...)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	header, types, n, err = inferRecords(c)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	if len(header) == 0 {
+		return nil, nil, 0, fmt.Errorf("no row found in %v", file)
+	}
+	return header, types, n, nil
+}
+
+// inferRecords read every record of c then infer the type of each column, n is the number of rows
+func inferRecords(c *Client[Record]) (header []string, types []*columnType, n int, err error) {
+	rows := c.CsvToRows()
+	defer rows.Close()
+
+	for rows.Next() {
+		r, err := rows.Read()
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, nil, 0, err
+		}
+		if r == nil {
+			continue
+		}
+		if len(r.Header()) > len(header) {
+			header = r.Header()
+		}
+		for i, val := range r.Values() {
+			if i >= len(types) {
+				t := newColumnType()
+				if n > 0 {
+					//the missing value of previous rows is null
+					t.count, t.nulls, t.minLen = n, n, 0
+				}
+				types = append(types, t)
+			}
+			types[i].add(val)
+		}
+		n++
+	}
+	return header, types, n, nil
+}
+
+// goFieldName convert header such as "FIRST NAME" or "create_at" to exported Go name
+func goFieldName(header string, col int) string {
+	parts := strings.FieldsFunc(header, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	var b strings.Builder
+	for _, p := range parts {
+		upper := strings.ToUpper(p)
+		if initialisms[upper] {
+			b.WriteString(upper)
+			continue
+		}
+		if p == upper {
+			//ALL CAPS word
+			p = strings.ToLower(p)
+		}
+		r := []rune(p)
+		r[0] = unicode.ToUpper(r[0])
+		b.WriteString(string(r))
+	}
+	name := b.String()
+	if name == "" {
+		return "Col" + strconv.Itoa(col)
+	}
+	if first := []rune(name)[0]; !unicode.IsLetter(first) || !unicode.IsUpper(first) {
+		return "Col" + name
+	}
+	return name
+}
@@ -0,0 +1,91 @@
+package csvtogo
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+)
+
+func Test_GenerateStruct(t *testing.T) {
+	tt := []struct {
+		name      string
+		ops       []*Options
+		content   string
+		expectedR string
+		expectedE error
+	}{
+		{
+			name: "should infer type, nullable and layout of each column",
+			content: "ID,FIRST NAME,create_at,SALARY,MARRIED,AGE,NOTE\n" +
+				"1,John,2022-01-31,10.5,true,,\n" +
+				"2,Sarah,2022-02-01,12,false,21,x\n",
+			expectedR: "type Customer struct {\n" +
+				"\tID        int       `csv:\"ID\"`\n" +
+				"\tFirstName string    `csv:\"FIRST NAME\" min:\"1\" max:\"5\"`\n" +
+				"\tCreateAt  time.Time `csv:\"create_at\" layout:\"2006-01-02\"`\n" +
+				"\tSalary    float64   `csv:\"SALARY\"`\n" +
+				"\tMarried   bool      `csv:\"MARRIED\"`\n" +
+				"\tAge       *int      `csv:\"AGE\"`\n" +
+				"\tNote      string    `csv:\"NOTE\" max:\"1\"`\n" +
+				"}\n",
+			expectedE: nil,
+		},
+		{
+			name:    "should use column index when no header",
+			ops:     []*Options{{SkipHeader: false, Comma: ','}},
+			content: "1,John\n",
+			expectedR: "type Customer struct {\n" +
+				"\tCol0 int\n" +
+				"\tCol1 string `min:\"1\" max:\"4\"`\n" +
+				"}\n",
+			expectedE: nil,
+		},
+		{
+			name:      "should return err when file has only header",
+			content:   "ID,NAME\n",
+			expectedR: "",
+			expectedE: errors.New("no row found in ./gen_test.csv"),
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			err := os.WriteFile("./gen_test.csv", []byte(tc.content), 0644)
+			if err != nil {
+				panic(err)
+			}
+			defer os.Remove("./gen_test.csv")
+
+			r, e := GenerateStruct("./gen_test.csv", "Customer", tc.ops...)
+			if fmt.Sprintf("%v", tc.expectedE) != fmt.Sprintf("%v", e) {
+				t.Errorf("must:%v, but got: %v", tc.expectedE, e)
+			}
+			if tc.expectedR != r {
+				t.Errorf("must:\n%v\nbut got:\n%v", tc.expectedR, r)
+			}
+		})
+	}
+}
+
+func Test_goFieldName(t *testing.T) {
+	tt := []struct {
+		header    string
+		expectedR string
+	}{
+		{header: "FIRST NAME", expectedR: "FirstName"},
+		{header: "create_at", expectedR: "CreateAt"},
+		{header: "customerId", expectedR: "CustomerId"},
+		{header: "user id", expectedR: "UserID"},
+		{header: "1st", expectedR: "Col1st"},
+		{header: "ราคา", expectedR: "Colราคา"},
+		{header: "", expectedR: "Col3"},
+	}
+	for _, tc := range tt {
+		t.Run("should convert "+tc.header+" to Go name", func(t *testing.T) {
+			r := goFieldName(tc.header, 3)
+			if tc.expectedR != r {
+				t.Errorf("must:%v, but got: %v", tc.expectedR, r)
+			}
+		})
+	}
+}
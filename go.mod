@@ -1,3 +1,8 @@
 module github.com/rkritchat/csvtogo
 
 go 1.18
+
+require (
+	github.com/klauspost/compress v1.17.2
+	gopkg.in/yaml.v3 v3.0.1
+)
@@ -0,0 +1,30 @@
+package csvtogo
+
+import "fmt"
+
+// preTransform apply Options.PreTransform to the raw record before conversion
+func (o Options) preTransform(data []string, row int) ([]string, error) {
+	if o.PreTransform == nil {
+		return data, nil
+	}
+	r, err := o.PreTransform(data)
+	if err != nil {
+		return nil, rowError(row, "", "", fmt.Errorf("failed to transform row: %v, %w", row, err))
+	}
+	return r, nil
+}
+
+// postConvert apply the transform of WithPostTransform then the filter of WithFilter to the converted value, keep is false when the row is filtered
+func (c *Executor[T]) postConvert(v *T, row int) (bool, error) {
+	if c.post != nil {
+		err := c.post(v)
+		if err != nil {
+			return false, rowError(row, "", "", fmt.Errorf("failed to transform row: %v, %w", row, err))
+		}
+	}
+	if c.filter != nil && !c.filter(v) {
+		c.ops.stats.filter()
+		return false, nil
+	}
+	return true, nil
+}
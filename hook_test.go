@@ -0,0 +1,98 @@
+package csvtogo
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+)
+
+func Test_Transform(t *testing.T) {
+	type Product struct {
+		Code  string  `csv:"code" max:"3"`
+		Price float64 `csv:"price"`
+		Tax   float64 `csv:"-"`
+	}
+	tt := []struct {
+		name      string
+		ops       *Options
+		post      func(*Product) error
+		content   string
+		expectedR string
+		expectedE error
+	}{
+		{
+			name: "should transform raw record then value",
+			ops: &Options{
+				SkipHeader: true,
+				PreTransform: func(d []string) ([]string, error) {
+					d[0] = strings.ToUpper(d[0])
+					d[1] = strings.TrimPrefix(d[1], "$")
+					return d, nil
+				},
+			},
+			post: func(p *Product) error {
+				p.Tax = p.Price * 0.07
+				return nil
+			},
+			content:   "code,price\nabc,$100\n",
+			expectedR: "[{ABC 100 7.000000000000001}]",
+		},
+		{
+			name: "should validate value after post transform",
+			ops:  &Options{SkipHeader: true},
+			post: func(p *Product) error {
+				p.Code += "-X"
+				return nil
+			},
+			content:   "code,price\nabc,100\n",
+			expectedE: errors.New("value of Code at row 1 is invalid, value length must less than or equal 3, but got: 5"),
+		},
+		{
+			name: "should return error of pre transform with row",
+			ops: &Options{
+				SkipHeader: true,
+				PreTransform: func(d []string) ([]string, error) {
+					return nil, errors.New("unknown currency")
+				},
+			},
+			content:   "code,price\nabc,€100\n",
+			expectedE: errors.New("failed to transform row: 1, unknown currency"),
+		},
+		{
+			name: "should return error of post transform with row",
+			ops:  &Options{SkipHeader: true},
+			post: func(p *Product) error {
+				return errors.New("price is zero")
+			},
+			content:   "code,price\nabc,0\n",
+			expectedE: errors.New("failed to transform row: 1, price is zero"),
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			err := os.WriteFile("./hook_test.csv", []byte(tc.content), 0644)
+			if err != nil {
+				panic(err)
+			}
+			defer os.Remove("./hook_test.csv")
+
+			c, _ := NewClient[Product]("./hook_test.csv", tc.ops)
+			r, e := c.WithPostTransform(tc.post).CsvToStruct()
+			if fmt.Sprintf("%v", tc.expectedE) != fmt.Sprintf("%v", e) {
+				t.Errorf("must:%v, but got: %v", tc.expectedE, e)
+			}
+			if tc.expectedE != nil {
+				return
+			}
+			var s []string
+			for _, p := range r {
+				s = append(s, fmt.Sprintf("%v", *p))
+			}
+			if tc.expectedR != "["+strings.Join(s, " ")+"]" {
+				t.Errorf("must:%v, but got: %v", tc.expectedR, s)
+			}
+		})
+	}
+}
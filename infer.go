@@ -0,0 +1,224 @@
+package csvtogo
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	TypeInt    = "int"
+	TypeFloat  = "float"
+	TypeBool   = "bool"
+	TypeTime   = "time"
+	TypeString = "string"
+)
+
+// timeLayouts are the layouts to try when infer time column, order by priority
+var timeLayouts = []string{
+	time.RFC3339Nano,
+	time.RFC3339,
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+	"2006/01/02",
+	"02/01/2006",
+	"01/02/2006",
+	"02-01-2006",
+}
+
+// maxSamples is number of sample values that kept in each column
+const maxSamples = 5
+
+// maxDistinct is number of distinct values that tracked in each column, the memory of unique column such as ID is bounded
+const maxDistinct = 10000
+
+// columnType infer the type of column from every value
+type columnType struct {
+	count   int
+	nulls   int
+	isInt   bool
+	isFloat bool
+	isBool  bool
+	layouts []string //layouts that parse every value
+
+	//JSON
+	isNumber   bool //every value is JSON number, 01234, +1 and NaN are not
+	isBoolWord bool //every value is true or false
+	minLen     int
+	maxLen     int
+
+	//profile
+	distinct  map[string]struct{}
+	capped    bool //some distinct value is not tracked, see maxDistinct
+	samples   []string
+	hasNum    bool
+	numMin    float64
+	numMax    float64
+	strMin    string
+	strMax    string
+	timeRange map[string][2]time.Time //min and max of each layout
+}
+
+func newColumnType() *columnType {
+	return &columnType{
+		isInt:      true,
+		isFloat:    true,
+		isBool:     true,
+		isNumber:   true,
+		isBoolWord: true,
+		layouts:    timeLayouts,
+		minLen:     -1,
+		distinct:   make(map[string]struct{}),
+		timeRange:  make(map[string][2]time.Time),
+	}
+}
+
+func (c *columnType) add(val string) {
+	c.count++
+	l := len([]rune(val))
+	if c.minLen < 0 || l < c.minLen {
+		c.minLen = l
+	}
+	if l > c.maxLen {
+		c.maxLen = l
+	}
+
+	val = strings.TrimSpace(val)
+	if val == "" {
+		c.nulls++
+		return
+	}
+	if _, ok := c.distinct[val]; !ok {
+		if len(c.distinct) < maxDistinct {
+			c.distinct[val] = struct{}{}
+		} else {
+			c.capped = true
+		}
+		if len(c.samples) < maxSamples {
+			c.samples = append(c.samples, val)
+		}
+	}
+	if c.count-c.nulls == 1 || val < c.strMin {
+		c.strMin = val
+	}
+	if val > c.strMax {
+		c.strMax = val
+	}
+
+	if c.isInt {
+		_, err := strconv.Atoi(val)
+		c.isInt = err == nil
+	}
+	if c.isFloat {
+		f, err := strconv.ParseFloat(val, 64)
+		c.isFloat = err == nil
+		if c.isFloat && (!c.hasNum || f < c.numMin) {
+			c.numMin = f
+		}
+		if c.isFloat && (!c.hasNum || f > c.numMax) {
+			c.numMax = f
+		}
+		c.hasNum = c.isFloat
+	}
+	if c.isBool {
+		_, err := strconv.ParseBool(val)
+		c.isBool = err == nil
+	}
+	if c.isNumber {
+		c.isNumber = isJSONNumber(val)
+	}
+	if c.isBoolWord {
+		c.isBoolWord = strings.EqualFold(val, "true") || strings.EqualFold(val, "false")
+	}
+	if len(c.layouts) > 0 {
+		var layouts []string
+		for _, layout := range c.layouts {
+			t, err := time.Parse(layout, val)
+			if err != nil {
+				continue
+			}
+			layouts = append(layouts, layout)
+			r, ok := c.timeRange[layout]
+			if !ok || t.Before(r[0]) {
+				r[0] = t
+			}
+			if !ok || t.After(r[1]) {
+				r[1] = t
+			}
+			c.timeRange[layout] = r
+		}
+		c.layouts = layouts
+	}
+}
+
+// kind return the inferred type, the column that every value is empty is string
+func (c *columnType) kind() string {
+	switch {
+	case c.count == c.nulls:
+		return TypeString
+	case c.isInt:
+		return TypeInt
+	case c.isFloat:
+		return TypeFloat
+	case c.isBool:
+		return TypeBool
+	case len(c.layouts) > 0:
+		return TypeTime
+	}
+	return TypeString
+}
+
+// layout return the time layout of time column
+func (c *columnType) layout() string {
+	if len(c.layouts) > 0 {
+		return c.layouts[0]
+	}
+	return ""
+}
+
+func (c *columnType) nullable() bool {
+	return c.nulls > 0
+}
+
+// minMax return the minimum and maximum value in the inferred type
+func (c *columnType) minMax() (string, string) {
+	switch c.kind() {
+	case TypeInt, TypeFloat:
+		return strconv.FormatFloat(c.numMin, 'f', -1, 64), strconv.FormatFloat(c.numMax, 'f', -1, 64)
+	case TypeTime:
+		r := c.timeRange[c.layout()]
+		return r[0].Format(c.layout()), r[1].Format(c.layout())
+	}
+	return c.strMin, c.strMax
+}
+
+// InferSchema infer the rules of each column from file such as type, layout and max length,
+// the schema can be used to validate other files that have the same format
+func InferSchema(file string, ops ...*Options) (*Schema, error) {
+	header, types, _, err := inferColumns(file, ops...)
+	if err != nil {
+		return nil, err
+	}
+	s := &Schema{}
+	for i, h := range header {
+		t := types[i]
+		sc := &SchemaColumn{Name: h, Type: t.kind()}
+		if len(ops) > 0 && !ops[0].SkipHeader {
+			idx := i
+			sc.Name, sc.Index = "", &idx
+		}
+		if sc.Type == TypeTime {
+			sc.Layout = t.layout()
+		}
+		if sc.Type == TypeString {
+			maxLen := t.maxLen
+			sc.Max = &maxLen
+		}
+		if !t.nullable() && t.count > 0 {
+			minLen := 1
+			sc.Min = &minLen
+		}
+		s.Columns = append(s.Columns, sc)
+	}
+	return s, nil
+}
@@ -0,0 +1,93 @@
+package csvtogo
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+)
+
+func Test_InferSchema(t *testing.T) {
+	err := os.WriteFile("./infer_test.csv", []byte("ID,NAME,CREATE_AT,NOTE\n1,John,2022-01-31,\n2,Sarah,2022-02-01,x\n"), 0644)
+	if err != nil {
+		panic(err)
+	}
+	defer os.Remove("./infer_test.csv")
+
+	s, e := InferSchema("./infer_test.csv")
+	if e != nil {
+		t.Errorf("must:nil, but got: %v", e)
+	}
+	b, _ := json.Marshal(s)
+	expectedR := `{"columns":[` +
+		`{"name":"ID","type":"int","min":1},` +
+		`{"name":"NAME","type":"string","min":1,"max":5},` +
+		`{"name":"CREATE_AT","type":"time","layout":"2006-01-02","min":1},` +
+		`{"name":"NOTE","type":"string","max":1}]}`
+	if expectedR != string(b) {
+		t.Errorf("must:%v, but got: %v", expectedR, string(b))
+	}
+}
+
+func Test_columnType(t *testing.T) {
+	tt := []struct {
+		name           string
+		values         []string
+		expectedKind   string
+		expectedLayout string
+		expectedMin    string
+		expectedMax    string
+	}{
+		{
+			name:         "should infer int",
+			values:       []string{"10", "-2", "", "7"},
+			expectedKind: TypeInt,
+			expectedMin:  "-2",
+			expectedMax:  "10",
+		},
+		{
+			name:         "should infer float when some value has decimal",
+			values:       []string{"10", "2.5"},
+			expectedKind: TypeFloat,
+			expectedMin:  "2.5",
+			expectedMax:  "10",
+		},
+		{
+			name:         "should infer bool",
+			values:       []string{"true", "F"},
+			expectedKind: TypeBool,
+			expectedMin:  "F",
+			expectedMax:  "true",
+		},
+		{
+			name:           "should infer time with the layout that parse every value",
+			values:         []string{"31/01/2022", "01/02/2022"},
+			expectedKind:   TypeTime,
+			expectedLayout: "02/01/2006",
+			expectedMin:    "31/01/2022",
+			expectedMax:    "01/02/2022",
+		},
+		{
+			name:         "should infer string when every value is empty",
+			values:       []string{"", ""},
+			expectedKind: TypeString,
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			c := newColumnType()
+			for _, v := range tc.values {
+				c.add(v)
+			}
+			if tc.expectedKind != c.kind() {
+				t.Errorf("must:%v, but got: %v", tc.expectedKind, c.kind())
+			}
+			if tc.expectedKind == TypeTime && tc.expectedLayout != c.layout() {
+				t.Errorf("must:%v, but got: %v", tc.expectedLayout, c.layout())
+			}
+			minimum, maximum := c.minMax()
+			if tc.expectedMin != minimum || tc.expectedMax != maximum {
+				t.Errorf("must:%v-%v, but got: %v-%v", tc.expectedMin, tc.expectedMax, minimum, maximum)
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package csvtogo
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+// Report is the profile of csv file that returned from Inspect
+type Report struct {
+	File    string          `json:"file"`
+	Rows    int             `json:"rows"`
+	Columns []ColumnProfile `json:"columns"`
+}
+
+// ColumnProfile is the profile of each column, Min and Max are compared in the inferred type.
+// Distinct is exact up to 10,000 values per column, then the tracking stop and DistinctCapped is true,
+// the real number of distinct values is more than Distinct
+type ColumnProfile struct {
+	Name           string   `json:"name"`
+	Type           string   `json:"type"`
+	Layout         string   `json:"layout,omitempty"`
+	Nullable       bool     `json:"nullable"`
+	NullRatio      float64  `json:"null_ratio"`
+	Min            string   `json:"min"`
+	Max            string   `json:"max"`
+	Distinct       int      `json:"distinct"`
+	DistinctCapped bool     `json:"distinct_capped"`
+	MaxLength      int      `json:"max_length"`
+	Samples        []string `json:"samples"`
+}
+
+// Inspect stream every row of file once and return the profile of each column
+func Inspect(file string, ops ...*Options) (*Report, error) {
+	header, types, n, err := inferColumns(file, ops...)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &Report{File: file, Rows: n}
+	for i, h := range header {
+		t := types[i]
+		//the column that missing in some rows is null
+		nulls := t.nulls + n - t.count
+		minimum, maximum := t.minMax()
+		r.Columns = append(r.Columns, ColumnProfile{
+			Name:           h,
+			Type:           t.kind(),
+			Layout:         t.layout(),
+			Nullable:       nulls > 0,
+			NullRatio:      float64(nulls) / float64(n),
+			Min:            minimum,
+			Max:            maximum,
+			Distinct:       len(t.distinct),
+			DistinctCapped: t.capped,
+			MaxLength:      t.maxLen,
+			Samples:        t.samples,
+		})
+		if r.Columns[i].Type != TypeTime {
+			r.Columns[i].Layout = ""
+		}
+	}
+	return r, nil
+}
+
+// WriteTable write the report as text table
+func (r *Report) WriteTable(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "file: %v, rows: %v\n", r.File, r.Rows)
+	fmt.Fprintln(tw, "NAME\tTYPE\tNULL RATIO\tMIN\tMAX\tDISTINCT\tMAX LENGTH\tSAMPLES")
+	for _, c := range r.Columns {
+		t := c.Type
+		if c.Layout != "" {
+			t = fmt.Sprintf("%v(%v)", c.Type, c.Layout)
+		}
+		if c.Nullable {
+			t += "?"
+		}
+		distinct := strconv.Itoa(c.Distinct)
+		if c.DistinctCapped {
+			distinct += "+"
+		}
+		fmt.Fprintf(tw, "%v\t%v\t%.2f\t%v\t%v\t%v\t%v\t%v\n",
+			c.Name,
+			t,
+			c.NullRatio,
+			c.Min,
+			c.Max,
+			distinct,
+			c.MaxLength,
+			strings.Join(c.Samples, ", "),
+		)
+	}
+	return tw.Flush()
+}
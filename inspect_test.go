@@ -0,0 +1,127 @@
+package csvtogo
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+)
+
+func Test_Inspect(t *testing.T) {
+	tt := []struct {
+		name      string
+		content   string
+		expectedR []ColumnProfile
+		expectedE error
+	}{
+		{
+			name: "should return profile of each column",
+			content: "ID,NAME,SALARY,CREATE_AT\n" +
+				"10,John,10.5,2022-01-31\n" +
+				"9,Sarah,,2022-02-01\n" +
+				"100,John,-2,2021-12-31\n" +
+				"3,Ann,7,2022-01-01\n",
+			expectedR: []ColumnProfile{
+				{Name: "ID", Type: TypeInt, Min: "3", Max: "100", Distinct: 4, MaxLength: 3},
+				{Name: "NAME", Type: TypeString, Min: "Ann", Max: "Sarah", Distinct: 3, MaxLength: 5},
+				{Name: "SALARY", Type: TypeFloat, Nullable: true, NullRatio: 0.25, Min: "-2", Max: "10.5", Distinct: 3, MaxLength: 4},
+				{Name: "CREATE_AT", Type: TypeTime, Layout: "2006-01-02", Min: "2021-12-31", Max: "2022-02-01", Distinct: 4, MaxLength: 10},
+			},
+			expectedE: nil,
+		},
+		{
+			name:      "should return err when file has only header",
+			content:   "ID,NAME\n",
+			expectedR: nil,
+			expectedE: errors.New("no row found in ./inspect_test.csv"),
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			err := os.WriteFile("./inspect_test.csv", []byte(tc.content), 0644)
+			if err != nil {
+				panic(err)
+			}
+			defer os.Remove("./inspect_test.csv")
+
+			r, e := Inspect("./inspect_test.csv")
+			if fmt.Sprintf("%v", tc.expectedE) != fmt.Sprintf("%v", e) {
+				t.Errorf("must:%v, but got: %v", tc.expectedE, e)
+			}
+			if r == nil {
+				return
+			}
+			if r.Rows != 4 {
+				t.Errorf("must:4, but got: %v", r.Rows)
+			}
+			for i, c := range r.Columns {
+				//samples order depend on reading order
+				if len(c.Samples) != c.Distinct {
+					t.Errorf("must:%v samples, but got: %v", c.Distinct, c.Samples)
+				}
+				c.Samples = nil
+				if fmt.Sprintf("%+v", tc.expectedR[i]) != fmt.Sprintf("%+v", c) {
+					t.Errorf("must:%+v, but got: %+v", tc.expectedR[i], c)
+				}
+			}
+		})
+	}
+}
+
+func Test_Inspect_distinctCapped(t *testing.T) {
+	var b strings.Builder
+	b.WriteString("ID,STATUS\n")
+	for i := 0; i < maxDistinct+5; i++ {
+		fmt.Fprintf(&b, "%v,A\n", i)
+	}
+	err := os.WriteFile("./inspect_capped_test.csv", []byte(b.String()), 0644)
+	if err != nil {
+		panic(err)
+	}
+	defer os.Remove("./inspect_capped_test.csv")
+
+	r, e := Inspect("./inspect_capped_test.csv")
+	if e != nil {
+		t.Fatalf("must:nil, but got: %v", e)
+	}
+	expectedR := []string{
+		fmt.Sprintf("ID %v true 0 %v", maxDistinct, maxDistinct+4),
+		"STATUS 1 false A A",
+	}
+	for i, c := range r.Columns {
+		got := fmt.Sprintf("%v %v %v %v %v", c.Name, c.Distinct, c.DistinctCapped, c.Min, c.Max)
+		if expectedR[i] != got {
+			t.Errorf("must:%v, but got: %v", expectedR[i], got)
+		}
+	}
+}
+
+func Test_Report_WriteTable(t *testing.T) {
+	r := &Report{
+		File: "sample.csv",
+		Rows: 2,
+		Columns: []ColumnProfile{
+			{Name: "ID", Type: TypeInt, Min: "1", Max: "2", Distinct: 2, MaxLength: 1, Samples: []string{"1", "2"}},
+			{Name: "CREATE_AT", Type: TypeTime, Layout: "2006-01-02", Nullable: true, NullRatio: 0.5, Min: "2022-01-31", Max: "2022-01-31", Distinct: 1, MaxLength: 10, Samples: []string{"2022-01-31"}},
+			{Name: "CODE", Type: TypeString, Min: "a", Max: "b", Distinct: 2, DistinctCapped: true, MaxLength: 1, Samples: []string{"a", "b"}},
+		},
+	}
+	var b bytes.Buffer
+	err := r.WriteTable(&b)
+	if err != nil {
+		t.Errorf("must:nil, but got: %v", err)
+	}
+	expectedR := []string{
+		"file: sample.csv, rows: 2",
+		"NAME       TYPE               NULL RATIO  MIN         MAX         DISTINCT  MAX LENGTH  SAMPLES",
+		"ID         int                0.00        1           2           2         1           1, 2",
+		"CREATE_AT  time(2006-01-02)?  0.50        2022-01-31  2022-01-31  1         10          2022-01-31",
+		"CODE       string             0.00        a           b           2+        1           a, b",
+		"",
+	}
+	if strings.Join(expectedR, "\n") != b.String() {
+		t.Errorf("must:\n%v\nbut got:\n%v", strings.Join(expectedR, "\n"), b.String())
+	}
+}
@@ -0,0 +1,5 @@
+// Package sqlitetest test Load and WriteSQL against the real SQLite database.
+// It is the separate module so csvtogo doesn't depend on the SQLite driver, run it by
+//
+//	cd internal/sqlitetest && go test ./...
+package sqlitetest
@@ -0,0 +1,145 @@
+package sqlitetest
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/rkritchat/csvtogo"
+	_ "modernc.org/sqlite"
+)
+
+type Customer struct {
+	Name  string  `csv:"name" db:"full name"`
+	Age   *int    `csv:"age"`
+	Note  string  `csv:"note" db:"-"`
+	Email *string `csv:"email"`
+}
+
+const table = `CREATE TABLE "customer" ("full name" TEXT NOT NULL CHECK ("full name" <> 'fail'), "age" INTEGER, "email" TEXT)`
+
+// open create the empty customer table in the new database
+func open() *sql.DB {
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		panic(err)
+	}
+	db.SetMaxOpenConns(1) //every connection of :memory: is the new database
+	_, err = db.Exec(table)
+	if err != nil {
+		panic(err)
+	}
+	return db
+}
+
+// rows return every row of customer table order by rowid
+func rows(db *sql.DB) string {
+	r, err := db.Query(`SELECT "full name", "age", "email" FROM "customer" ORDER BY rowid`)
+	if err != nil {
+		panic(err)
+	}
+	defer r.Close()
+	var out []string
+	for r.Next() {
+		var name string
+		var age sql.NullInt64
+		var email sql.NullString
+		err = r.Scan(&name, &age, &email)
+		if err != nil {
+			panic(err)
+		}
+		out = append(out, fmt.Sprintf("%v %v %v", name, age.Int64, email.String))
+	}
+	return strings.Join(out, "|")
+}
+
+func Test_Load(t *testing.T) {
+	tt := []struct {
+		name         string
+		ops          []*csvtogo.LoadOptions
+		content      string
+		expectedR    string
+		expectedE    error
+		expectedRows string
+	}{
+		{
+			name:         "should insert by batch and report the failed rows",
+			ops:          []*csvtogo.LoadOptions{{BatchSize: 2}},
+			content:      "name,age,note,email\nSarah,12,x,a@b.c\nfail,21,x,\nJohn,,x,\nJane,x,x,\nTom,3,x,\n",
+			expectedR:    "3 [2 4]",
+			expectedRows: "Sarah 12 a@b.c|John 0 |Tom 3 ",
+		},
+		{
+			name:         "should use dollar placeholder",
+			ops:          []*csvtogo.LoadOptions{{Dialect: csvtogo.DialectSQLite, Placeholder: "$"}},
+			content:      "name,age,note,email\nSarah,12,x,\nJohn,21,x,\n",
+			expectedR:    "2 []",
+			expectedRows: "Sarah 12 |John 21 ",
+		},
+		{
+			name:         "should rollback every row when all or nothing",
+			ops:          []*csvtogo.LoadOptions{{BatchSize: 1, Mode: csvtogo.LoadAllOrNothing}},
+			content:      "name,age,note,email\nSarah,12,x,\nJohn,21,x,\nfail,1,x,\nJane,1,x,\n",
+			expectedR:    "0 [3]",
+			expectedE:    errors.New("failed to load row 3, every row is rolled back: constraint failed: CHECK constraint failed: full name (275)"),
+			expectedRows: "",
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			db := open()
+			defer db.Close()
+			err := os.WriteFile("./load_test.csv", []byte(tc.content), 0644)
+			if err != nil {
+				panic(err)
+			}
+			defer os.Remove("./load_test.csv")
+
+			c, _ := csvtogo.NewClient[Customer]("./load_test.csv")
+			r, e := csvtogo.Load(context.Background(), db, "customer", c, tc.ops...)
+			if fmt.Sprintf("%v", tc.expectedE) != fmt.Sprintf("%v", e) {
+				t.Errorf("must:%v, but got: %v", tc.expectedE, e)
+			}
+			var failed []int
+			for _, f := range r.Failed {
+				failed = append(failed, f.Row)
+			}
+			if tc.expectedR != fmt.Sprintf("%v %v", r.Inserted, failed) {
+				t.Errorf("must:%v, but got: %v %v", tc.expectedR, r.Inserted, failed)
+			}
+			if tc.expectedRows != rows(db) {
+				t.Errorf("must:%v, but got: %v", tc.expectedRows, rows(db))
+			}
+		})
+	}
+}
+
+func Test_WriteSQL(t *testing.T) {
+	db := open()
+	defer db.Close()
+	err := os.WriteFile("./sql_test.csv", []byte("name,age,note,email\nO'Neil,12,x,a@b.c\nJohn,,x,\nJane,3,x,\n"), 0644)
+	if err != nil {
+		panic(err)
+	}
+	defer os.Remove("./sql_test.csv")
+
+	c, _ := csvtogo.NewClient[Customer]("./sql_test.csv")
+	var buf bytes.Buffer
+	err = csvtogo.WriteSQL(&buf, "customer", c, &csvtogo.SQLOptions{Dialect: csvtogo.DialectSQLite, BatchSize: 2})
+	if err != nil {
+		t.Fatalf("must:%v, but got: %v", nil, err)
+	}
+	_, err = db.Exec(buf.String())
+	if err != nil {
+		t.Fatalf("must:%v, but got: %v", nil, err)
+	}
+	expected := "O'Neil 12 a@b.c|John 0 |Jane 3 "
+	if expected != rows(db) {
+		t.Errorf("must:%v, but got: %v", expected, rows(db))
+	}
+}
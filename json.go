@@ -0,0 +1,231 @@
+package csvtogo
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ConvertToJSON write every row of csv from r to w as JSON array, the key of each object is the header.
+// The value is typed by Type of schema column if Options.Schema is set, the column without schema is string.
+// Otherwise the type of each column is inferred from every value, r is copied to temporary file to read it twice.
+// The column is number or bool only when every value can be written as is, such as 01234 and NaN keep the column as string.
+func ConvertToJSON(r io.Reader, w io.Writer, ops ...*Options) error {
+	return convertJSON(r, w, false, ops...)
+}
+
+// ConvertToNDJSON same as ConvertToJSON but write one JSON object per line
+func ConvertToNDJSON(r io.Reader, w io.Writer, ops ...*Options) error {
+	return convertJSON(r, w, true, ops...)
+}
+
+func convertJSON(r io.Reader, w io.Writer, ndjson bool, ops ...*Options) error {
+	var types []*columnType
+	if len(ops) == 0 || ops[0] == nil || ops[0].Schema == nil {
+		f, err := os.CreateTemp("", "csvtogo-*")
+		if err != nil {
+			return err
+		}
+		defer os.Remove(f.Name())
+		defer f.Close()
+		types, err = inferReader(r, f, ops...)
+		if err != nil {
+			return err
+		}
+		r = f
+	}
+
+	c, err := NewDynamicClientReader(r, ops...)
+	if err != nil {
+		return err
+	}
+	rows := c.CsvToRows()
+	defer rows.Close()
+
+	bw := bufio.NewWriter(w)
+	n := 0
+	for rows.Next() {
+		rec, err := rows.Read()
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			return err
+		}
+		if rec == nil {
+			continue
+		}
+		b, err := recordJSON(*rec, recordColumns(c.ops, rec.header), types)
+		if err != nil {
+			return err
+		}
+		switch {
+		case ndjson:
+		case n == 0:
+			bw.WriteString("[\n")
+		default:
+			bw.WriteString(",\n")
+		}
+		bw.Write(b)
+		if ndjson {
+			bw.WriteByte('\n')
+		}
+		n++
+	}
+	switch {
+	case ndjson:
+	case n == 0:
+		bw.WriteString("[]\n")
+	default:
+		bw.WriteString("\n]\n")
+	}
+	return bw.Flush()
+}
+
+// inferReader copy r to f then infer the type of each column, f is rewound to read again
+func inferReader(r io.Reader, f *os.File, ops ...*Options) ([]*columnType, error) {
+	_, err := io.Copy(f, r)
+	if err != nil {
+		return nil, err
+	}
+	_, err = f.Seek(0, io.SeekStart)
+	if err != nil {
+		return nil, err
+	}
+	c, err := NewDynamicClientReader(f, ops...)
+	if err != nil {
+		return nil, err
+	}
+	_, types, _, err := inferRecords(c)
+	if err != nil {
+		return nil, err
+	}
+	_, err = f.Seek(0, io.SeekStart)
+	return types, err
+}
+
+// recordColumns return schema column of each value of record, nil if the column has no schema
+func recordColumns(ops Options, header []string) []*SchemaColumn {
+	if ops.Schema == nil {
+		return nil
+	}
+	r := make([]*SchemaColumn, len(header))
+	raw := 0
+	for i, h := range header {
+		for _, ok := ops.skipper[raw]; ok; _, ok = ops.skipper[raw] {
+			raw++
+		}
+		for _, sc := range ops.Schema.Columns {
+			//header is renamed by Field, see renameColumns
+			if (sc.Field != "" && sc.Field == h) ||
+				(sc.Field == "" && sc.Name != "" && strings.EqualFold(sc.Name, strings.TrimSpace(h))) ||
+				(sc.Name == "" && sc.Index != nil && *sc.Index == raw) {
+				r[i] = sc
+				break
+			}
+		}
+		raw++
+	}
+	return r
+}
+
+// recordJSON write record as JSON object that keep the order of header,
+// the value is typed by schema column or the inferred type of the column
+func recordJSON(r Record, columns []*SchemaColumn, types []*columnType) ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteByte('{')
+	for i, h := range r.header {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		k, err := json.Marshal(h)
+		if err != nil {
+			return nil, err
+		}
+		b.Write(k)
+		b.WriteByte(':')
+
+		kind := TypeString
+		switch {
+		case i < len(columns) && columns[i] != nil:
+			kind = columns[i].Type
+		case i < len(types):
+			kind = types[i].jsonKind()
+		}
+		var sc *SchemaColumn
+		if i < len(columns) {
+			sc = columns[i]
+		}
+		v, err := json.Marshal(jsonValue(r.values[i], kind, sc))
+		if err != nil {
+			return nil, err
+		}
+		b.Write(v)
+	}
+	b.WriteByte('}')
+	return b.Bytes(), nil
+}
+
+// jsonKind return the JSON type of column, number or bool only when every value can be written without losing data
+func (c *columnType) jsonKind() string {
+	switch {
+	case c.count == c.nulls:
+		return TypeString
+	case c.isNumber:
+		return TypeFloat
+	case c.isBoolWord:
+		return TypeBool
+	}
+	return TypeString
+}
+
+// jsonValue convert value by kind, empty value is null. The number is written as the text of value when it's JSON number,
+// the value that can't be written such as NaN is string
+func jsonValue(val, kind string, sc *SchemaColumn) interface{} {
+	if val == "" {
+		return nil
+	}
+	v := strings.TrimSpace(val)
+	switch kind {
+	case TypeInt, TypeFloat:
+		if isJSONNumber(v) {
+			return json.Number(v)
+		}
+		//such as +1 that is valid by schema
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+			//no NaN and Infinity in JSON
+			return val
+		}
+		return f
+	case TypeBool:
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return val
+		}
+		return b
+	case TypeTime:
+		if sc != nil && sc.Layout != "" {
+			t, err := time.Parse(sc.Layout, val)
+			if err != nil {
+				return val
+			}
+			return t.Format(time.RFC3339)
+		}
+	}
+	return val
+}
+
+// isJSONNumber return true if s is number in JSON syntax, such as 01234, +1, .5 and NaN are not
+func isJSONNumber(s string) bool {
+	if s == "" || (s[0] != '-' && (s[0] < '0' || s[0] > '9')) {
+		return false
+	}
+	return json.Valid([]byte(s))
+}
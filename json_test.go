@@ -0,0 +1,97 @@
+package csvtogo
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func Test_ConvertToJSON(t *testing.T) {
+	tt := []struct {
+		name      string
+		ndjson    bool
+		ops       []*Options
+		content   string
+		expectedR string
+		expectedE error
+	}{
+		{
+			name:      "should infer type of each column and keep the order of header",
+			ops:       []*Options{{SkipHeader: true}},
+			content:   "id,name,price,active\n1,Sarah,10.5,true\n2,,x,\n",
+			expectedR: "[\n{\"id\":1,\"name\":\"Sarah\",\"price\":\"10.5\",\"active\":true},\n{\"id\":2,\"name\":null,\"price\":\"x\",\"active\":null}\n]\n",
+		},
+		{
+			name:      "should keep the column as string when some value lose data as number or bool",
+			ndjson:    true,
+			ops:       []*Options{{SkipHeader: true}},
+			content:   "zip,flag,score,big\n01234,T,NaN,12345678901234567890.10\n10110,F,1.5,1e3\n",
+			expectedR: "{\"zip\":\"01234\",\"flag\":\"T\",\"score\":\"NaN\",\"big\":12345678901234567890.10}\n{\"zip\":\"10110\",\"flag\":\"F\",\"score\":\"1.5\",\"big\":1e3}\n",
+		},
+		{
+			name:   "should write non-finite float of schema as string",
+			ndjson: true,
+			ops: []*Options{{SkipHeader: true, Schema: &Schema{Columns: []*SchemaColumn{
+				{Name: "score", Type: TypeFloat},
+			}}}},
+			content:   "score,note\nInfinity,01\n+2,x\n",
+			expectedR: "{\"score\":\"Infinity\",\"note\":\"01\"}\n{\"score\":2,\"note\":\"x\"}\n",
+		},
+		{
+			name:      "should write one object per line when ndjson",
+			ndjson:    true,
+			ops:       []*Options{{SkipHeader: true, Comma: ';', SkipCols: []int{0}}},
+			content:   "id;name\n1;Sarah\n2;John\n",
+			expectedR: "{\"name\":\"Sarah\"}\n{\"name\":\"John\"}\n",
+		},
+		{
+			name:      "should use column index as key when SkipHeader is false",
+			ops:       []*Options{{SkipHeader: false}},
+			content:   "Sarah,12\n",
+			expectedR: "[\n{\"0\":\"Sarah\",\"1\":12}\n]\n",
+		},
+		{
+			name:      "should write empty array when no row found",
+			ops:       []*Options{{SkipHeader: true}},
+			content:   "id,name\n",
+			expectedR: "[]\n",
+		},
+		{
+			name: "should type value by schema",
+			ops: []*Options{{SkipHeader: true, Schema: &Schema{Columns: []*SchemaColumn{
+				{Name: "zip", Type: TypeString},
+				{Name: "born", Field: "birthday", Type: TypeTime, Layout: "02/01/2006"},
+				{Name: "score", Type: TypeFloat},
+			}}}},
+			content:   "zip,born,score\n01000,31/12/2000,1\n",
+			expectedR: "[\n{\"zip\":\"01000\",\"birthday\":\"2000-12-31T00:00:00Z\",\"score\":1}\n]\n",
+		},
+		{
+			name: "should return error when value is invalid by schema",
+			ops: []*Options{{SkipHeader: true, Schema: &Schema{Columns: []*SchemaColumn{
+				{Name: "id", Type: TypeInt},
+			}}}},
+			content:   "id\nx\n",
+			expectedR: "",
+			expectedE: fmt.Errorf("invalid csv value at row: 1, column id is not int"),
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var w bytes.Buffer
+			var e error
+			if tc.ndjson {
+				e = ConvertToNDJSON(strings.NewReader(tc.content), &w, tc.ops...)
+			} else {
+				e = ConvertToJSON(strings.NewReader(tc.content), &w, tc.ops...)
+			}
+			if fmt.Sprintf("%v", tc.expectedE) != fmt.Sprintf("%v", e) {
+				t.Errorf("must:%v, but got: %v", tc.expectedE, e)
+			}
+			if tc.expectedR != w.String() {
+				t.Errorf("must:%q, but got: %q", tc.expectedR, w.String())
+			}
+		})
+	}
+}
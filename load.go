@@ -0,0 +1,391 @@
+package csvtogo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const tagDb = "db"
+
+type LoadMode int
+
+const (
+	LoadCommitBatch  LoadMode = iota //commit every batch, the failed row is reported and skipped
+	LoadAllOrNothing                 //one transaction for every row, rollback everything if some row is failed
+)
+
+const defaultBatchSize = 100
+
+type LoadOptions struct {
+	BatchSize   int //number of rows per insert statement, default is 100
+	Mode        LoadMode
+	Dialect     string //DialectPostgres, DialectMySQL or DialectSQLite to quote table and column names, detect by Placeholder if empty
+	Placeholder string //"?" for MySQL and SQLite or "$" for Postgres such as $1, $2, detect by Dialect if empty
+}
+
+// LoadResult is the number of inserted rows and the rows that can't be inserted
+type LoadResult struct {
+	Inserted int
+	Failed   []FailedRow
+}
+
+// FailedRow is the row that rejected by database, Row is the row number of the file, see Executor.Row
+type FailedRow struct {
+	Row int
+	Err error
+}
+
+// dbColumn is the table column of struct field
+type dbColumn struct {
+	name  string
+	index []int
+	field reflect.StructField
+}
+
+// pending is the value that waiting to insert with its row number
+type pending struct {
+	args []interface{}
+	row  int
+}
+
+// Load read every row of c and insert into table by batch, the column is the db tag of each field,
+// or csv tag name / field name if no db tag, `db:"-"` is not inserted.
+// The table and column names are quoted by the dialect like WriteSQL, schema.table is also supported.
+// Slice and map are inserted as text joined by split and kv tag like the csv cell.
+// In LoadCommitBatch mode the invalid row such as wrong type or validation is also a failed row, the next rows are still loaded.
+// The error is returned when the rows can't be read, the transaction is failed or some row is failed in LoadAllOrNothing mode.
+func Load[T any](ctx context.Context, db *sql.DB, table string, c *Client[T], ops ...*LoadOptions) (*LoadResult, error) {
+	op := LoadOptions{BatchSize: defaultBatchSize}
+	if len(ops) > 0 && ops[0] != nil {
+		op = *ops[0]
+		if op.BatchSize <= 0 {
+			op.BatchSize = defaultBatchSize
+		}
+	}
+	switch {
+	case op.Dialect == "" && op.Placeholder == "$":
+		op.Dialect = DialectPostgres
+	case op.Dialect == "":
+		op.Dialect = DialectSQLite
+	}
+	switch op.Dialect {
+	case DialectPostgres, DialectMySQL, DialectSQLite:
+	default:
+		return nil, fmt.Errorf("dialect %v is not support", op.Dialect)
+	}
+	switch {
+	case op.Placeholder == "" && op.Dialect == DialectPostgres:
+		op.Placeholder = "$"
+	case op.Placeholder == "":
+		op.Placeholder = "?"
+	}
+	if op.Placeholder != "?" && op.Placeholder != "$" {
+		return nil, fmt.Errorf("placeholder %v is not support", op.Placeholder)
+	}
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	if t.Kind() != reflect.Struct || t == reflect.TypeOf(Record{}) {
+		return nil, fmt.Errorf("csvtogo is not support to load type %v, struct is required", t.String())
+	}
+	columns := dbColumns(t)
+	if len(columns) == 0 {
+		return nil, fmt.Errorf("no column found in %v", t.Name())
+	}
+
+	l := &loader{ctx: ctx, db: db, table: table, columns: columns, ops: op, result: &LoadResult{}}
+	if op.Mode == LoadAllOrNothing {
+		var err error
+		l.tx, err = db.BeginTx(ctx, nil)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if op.Mode == LoadCommitBatch {
+		//the invalid row is failed row, the reader skip it and continue
+		c.ops.invalidRow = l.invalidRow
+	}
+	rows := c.CsvToRows()
+	defer rows.Close()
+	var batch []pending
+	for rows.Next() {
+		v, err := rows.Read()
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			return l.fail(batch, err)
+		}
+		if v == nil {
+			continue
+		}
+		batch = append(batch, pending{args: dbValues(reflect.ValueOf(v).Elem(), columns), row: rows.Row()})
+		if len(batch) < op.BatchSize {
+			continue
+		}
+		err = l.insert(batch)
+		if err != nil {
+			return l.report(err)
+		}
+		batch = nil
+	}
+
+	err := l.insert(batch)
+	if err != nil {
+		return l.report(err)
+	}
+	if l.tx != nil {
+		err = l.tx.Commit()
+		if err != nil {
+			l.result.Inserted = 0
+			return l.result, err
+		}
+	}
+	return l.report(nil)
+}
+
+type loader struct {
+	ctx     context.Context
+	db      *sql.DB
+	tx      *sql.Tx //transaction of LoadAllOrNothing mode
+	table   string
+	columns []dbColumn
+	ops     LoadOptions
+	result  *LoadResult
+	mu      sync.Mutex
+	invalid []FailedRow //invalid rows that reported by the reader
+}
+
+// invalidRow is called by the reader when the row can't be converted or validated
+func (l *loader) invalidRow(e *RowError) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.invalid = append(l.invalid, FailedRow{Row: e.Row, Err: e})
+}
+
+// report add the invalid rows to the failed rows order by row
+func (l *loader) report(err error) (*LoadResult, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.result.Failed = append(l.result.Failed, l.invalid...)
+	l.invalid = nil
+	sort.SliceStable(l.result.Failed, func(i, j int) bool {
+		return l.result.Failed[i].Row < l.result.Failed[j].Row
+	})
+	return l.result, err
+}
+
+// insert the batch by one statement, the failed batch is retried row by row to find the failed rows
+func (l *loader) insert(batch []pending) error {
+	if len(batch) == 0 {
+		return nil
+	}
+	query, args := l.statement(batch)
+	if l.tx != nil {
+		_, err := l.tx.ExecContext(l.ctx, query, args...)
+		if err != nil {
+			_ = l.tx.Rollback()
+			f := l.probe(batch, err)
+			l.result.Inserted = 0
+			l.result.Failed = append(l.result.Failed, f)
+			return fmt.Errorf("failed to load row %v, every row is rolled back: %w", f.Row, f.Err)
+		}
+		l.result.Inserted += len(batch)
+		return nil
+	}
+
+	tx, err := l.db.BeginTx(l.ctx, nil)
+	if err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(l.ctx, query, args...)
+	if err == nil {
+		err = tx.Commit()
+		if err != nil {
+			return err
+		}
+		l.result.Inserted += len(batch)
+		return nil
+	}
+	_ = tx.Rollback()
+
+	//the transaction may be aborted by the error such as Postgres, retry each row by itself
+	for _, p := range batch {
+		query, args := l.statement([]pending{p})
+		_, err = l.db.ExecContext(l.ctx, query, args...)
+		if err != nil {
+			l.result.Failed = append(l.result.Failed, FailedRow{Row: p.row, Err: err})
+			continue
+		}
+		l.result.Inserted++
+	}
+	return nil
+}
+
+// probe insert each row of the failed batch in the transaction that always rolled back, then return the first failed row
+func (l *loader) probe(batch []pending, batchErr error) FailedRow {
+	tx, err := l.db.BeginTx(l.ctx, nil)
+	if err != nil {
+		return FailedRow{Row: batch[0].row, Err: batchErr}
+	}
+	defer tx.Rollback()
+	for _, p := range batch {
+		query, args := l.statement([]pending{p})
+		_, err = tx.ExecContext(l.ctx, query, args...)
+		if err != nil {
+			return FailedRow{Row: p.row, Err: err}
+		}
+	}
+	//the batch is failed as a whole such as the size of statement
+	return FailedRow{Row: batch[0].row, Err: batchErr}
+}
+
+// fail flush the pending rows before return the read error, or rollback every row in LoadAllOrNothing mode
+func (l *loader) fail(batch []pending, err error) (*LoadResult, error) {
+	if l.tx != nil {
+		_ = l.tx.Rollback()
+		l.result.Inserted = 0
+		return l.result, err
+	}
+	e := l.insert(batch)
+	if e != nil {
+		return l.report(e)
+	}
+	return l.report(err)
+}
+
+// statement return INSERT statement of the batch such as INSERT INTO t (a, b) VALUES (?, ?), (?, ?)
+func (l *loader) statement(batch []pending) (string, []interface{}) {
+	var b strings.Builder
+	names := make([]string, len(l.columns))
+	for i, c := range l.columns {
+		names[i] = quoteIdent(l.ops.Dialect, c.name)
+	}
+	fmt.Fprintf(&b, "INSERT INTO %v (%v) VALUES ", quoteIdent(l.ops.Dialect, l.table), strings.Join(names, ", "))
+
+	args := make([]interface{}, 0, len(batch)*len(l.columns))
+	for i, p := range batch {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		b.WriteByte('(')
+		for j := range p.args {
+			if j > 0 {
+				b.WriteString(", ")
+			}
+			args = append(args, p.args[j])
+			if l.ops.Placeholder == "$" {
+				b.WriteString("$" + strconv.Itoa(len(args)))
+				continue
+			}
+			b.WriteString("?")
+		}
+		b.WriteByte(')')
+	}
+	return b.String(), args
+}
+
+// dbColumns return the table column of each field
+func dbColumns(t reflect.Type) []dbColumn {
+	var columns []dbColumn
+	for _, f := range structFields(t) {
+		name := f.name
+		if tag, ok := f.field.Tag.Lookup(tagDb); ok {
+			name = strings.Split(tag, ",")[0]
+		}
+		if name == "-" || name == "" {
+			continue
+		}
+		columns = append(columns, dbColumn{name: name, index: f.index, field: f.field})
+	}
+	return columns
+}
+
+// dbValues return the value of each column, the field of nil nested struct or nil pointer is NULL,
+// slice and map are joined like the cell of csv, see joinCell
+func dbValues(v reflect.Value, columns []dbColumn) []interface{} {
+	args := make([]interface{}, len(columns))
+	for i, c := range columns {
+		fv, ok := lookupField(v, c.index)
+		if !ok || (fv.Kind() == reflect.Ptr && fv.IsNil()) {
+			continue
+		}
+		if isJoined(fv) {
+			args[i] = joinCell(fv, c.field)
+			continue
+		}
+		args[i] = fv.Interface()
+	}
+	return args
+}
+
+// isJoined return true if f is slice or map that split from a single cell, []byte is kept as is
+func isJoined(f reflect.Value) bool {
+	switch f.Kind() {
+	case reflect.Map:
+		return true
+	case reflect.Slice:
+		return f.Type().Elem().Kind() != reflect.Uint8
+	}
+	return false
+}
+
+// joinCell join the elements of slice or map by split and kv tag of sf, then setField read the cell back to the same value.
+// The entries of map are sorted by key
+func joinCell(f reflect.Value, sf reflect.StructField) string {
+	sep := splitTag(sf)
+	if f.Kind() == reflect.Slice {
+		items := make([]string, f.Len())
+		for i := range items {
+			items[i] = formatCell(f.Index(i), sf)
+		}
+		return strings.Join(items, sep)
+	}
+	kv := sf.Tag.Get(tagKv)
+	if kv == "" {
+		kv = defaultKv
+	}
+	items := make([]string, 0, f.Len())
+	iter := f.MapRange()
+	for iter.Next() {
+		items = append(items, formatCell(iter.Key(), sf)+kv+formatCell(iter.Value(), sf))
+	}
+	sort.Strings(items)
+	return strings.Join(items, sep)
+}
+
+// formatCell return the text of single value in the format of convert such as layout and time tag
+func formatCell(v reflect.Value, sf reflect.StructField) string {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return ""
+		}
+		v = v.Elem()
+	}
+	switch val := v.Interface().(type) {
+	case time.Time:
+		switch sf.Tag.Get(tagTime) {
+		case timeUnix:
+			return strconv.FormatInt(val.Unix(), 10)
+		case timeUnixMs:
+			return strconv.FormatInt(val.UnixMilli(), 10)
+		}
+		layout := sf.Tag.Get(tagLayout)
+		if layout == "" {
+			layout = time.RFC3339
+		}
+		return val.Format(layout)
+	case Decimal:
+		return val.String()
+	case time.Duration:
+		return val.String()
+	}
+	return fmt.Sprintf("%v", v.Interface())
+}
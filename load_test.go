@@ -0,0 +1,223 @@
+package csvtogo
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeStore is the table of fake driver, the row that has value "fail" is rejected
+type fakeStore struct {
+	mu        sync.Mutex
+	committed []driver.Value
+	queries   []string
+}
+
+var store = &fakeStore{}
+
+func (s *fakeStore) reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.committed, s.queries = nil, nil
+}
+
+type fakeDriver struct{}
+
+func (fakeDriver) Open(string) (driver.Conn, error) { return &fakeConn{}, nil }
+
+type fakeConn struct {
+	tx      bool
+	pending []driver.Value
+}
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return &fakeStmt{c: c, query: query}, nil
+}
+func (c *fakeConn) Close() error { return nil }
+func (c *fakeConn) Begin() (driver.Tx, error) {
+	c.tx = true
+	return c, nil
+}
+
+func (c *fakeConn) Commit() error {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	store.committed = append(store.committed, c.pending...)
+	c.tx, c.pending = false, nil
+	return nil
+}
+
+func (c *fakeConn) Rollback() error {
+	c.tx, c.pending = false, nil
+	return nil
+}
+
+type fakeStmt struct {
+	c     *fakeConn
+	query string
+}
+
+func (s *fakeStmt) Close() error  { return nil }
+func (s *fakeStmt) NumInput() int { return -1 }
+func (s *fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	store.queries = append(store.queries, s.query)
+	for _, a := range args {
+		if a == "fail" {
+			return nil, errors.New("constraint failed")
+		}
+	}
+	if s.c.tx {
+		s.c.pending = append(s.c.pending, args...)
+		return driver.RowsAffected(len(args)), nil
+	}
+	store.committed = append(store.committed, args...)
+	return driver.RowsAffected(len(args)), nil
+}
+
+func (s *fakeStmt) Query([]driver.Value) (driver.Rows, error) {
+	return nil, errors.New("not support")
+}
+
+func init() {
+	sql.Register("csvtogo-fake", fakeDriver{})
+}
+
+func Test_Load(t *testing.T) {
+	type Customer struct {
+		Name  string  `csv:"name" db:"full_name"`
+		Age   *int    `csv:"age"`
+		Note  string  `csv:"note" db:"-"`
+		Email *string `csv:"email"`
+	}
+	tt := []struct {
+		name            string
+		ops             []*LoadOptions
+		content         string
+		expectedR       *LoadResult
+		expectedE       error
+		expectedQuery   string
+		expectedCommits string
+	}{
+		{
+			name:            "should insert by batch and report the failed row",
+			ops:             []*LoadOptions{{BatchSize: 2}},
+			content:         "name,age,note,email\nSarah,12,x,a@b.c\nfail,21,x,\nJohn,,x,\n",
+			expectedR:       &LoadResult{Inserted: 2, Failed: []FailedRow{{Row: 2, Err: errors.New("constraint failed")}}},
+			expectedQuery:   "INSERT INTO \"customer\" (\"full_name\", \"age\", \"email\") VALUES (?, ?, ?)",
+			expectedCommits: "[Sarah 12 a@b.c John <nil> <nil>]",
+		},
+		{
+			name:            "should use dollar placeholder",
+			ops:             []*LoadOptions{{Placeholder: "$"}},
+			content:         "name,age,note,email\nSarah,12,x,\nJohn,21,x,\n",
+			expectedR:       &LoadResult{Inserted: 2},
+			expectedQuery:   "INSERT INTO \"customer\" (\"full_name\", \"age\", \"email\") VALUES ($1, $2, $3), ($4, $5, $6)",
+			expectedCommits: "[Sarah 12 <nil> John 21 <nil>]",
+		},
+		{
+			name:            "should quote names by dialect",
+			ops:             []*LoadOptions{{Dialect: DialectMySQL}},
+			content:         "name,age,note,email\nSarah,12,x,\n",
+			expectedR:       &LoadResult{Inserted: 1},
+			expectedQuery:   "INSERT INTO `customer` (`full_name`, `age`, `email`) VALUES (?, ?, ?)",
+			expectedCommits: "[Sarah 12 <nil>]",
+		},
+		{
+			name:            "should rollback every row when all or nothing",
+			ops:             []*LoadOptions{{BatchSize: 1, Mode: LoadAllOrNothing}},
+			content:         "name,age,note,email\nSarah,12,x,\nJohn,21,x,\nfail,1,x,\nJane,1,x,\n",
+			expectedR:       &LoadResult{Failed: []FailedRow{{Row: 3, Err: errors.New("constraint failed")}}},
+			expectedE:       errors.New("failed to load row 3, every row is rolled back: constraint failed"),
+			expectedQuery:   "INSERT INTO \"customer\" (\"full_name\", \"age\", \"email\") VALUES (?, ?, ?)",
+			expectedCommits: "[]",
+		},
+		{
+			name:            "should report invalid row as failed row and continue",
+			content:         "name,age,note,email\nSarah,12,x,\nJohn,x,x,\nJane,3,x,\n",
+			expectedR:       &LoadResult{Inserted: 2, Failed: []FailedRow{{Row: 2, Err: errors.New("invalid csv value at row: 2, the struct accept type int")}}},
+			expectedQuery:   "INSERT INTO \"customer\" (\"full_name\", \"age\", \"email\") VALUES (?, ?, ?), (?, ?, ?)",
+			expectedCommits: "[Sarah 12 <nil> Jane 3 <nil>]",
+		},
+		{
+			name:            "should rollback every row when invalid row and all or nothing",
+			ops:             []*LoadOptions{{BatchSize: 1, Mode: LoadAllOrNothing}},
+			content:         "name,age,note,email\nSarah,12,x,\nJohn,x,x,\nJane,3,x,\n",
+			expectedR:       &LoadResult{},
+			expectedE:       errors.New("invalid csv value at row: 2, the struct accept type int"),
+			expectedQuery:   "INSERT INTO \"customer\" (\"full_name\", \"age\", \"email\") VALUES (?, ?, ?)",
+			expectedCommits: "[]",
+		},
+	}
+	db, err := sql.Open("csvtogo-fake", "")
+	if err != nil {
+		panic(err)
+	}
+	defer db.Close()
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			store.reset()
+			err := os.WriteFile("./load_test.csv", []byte(tc.content), 0644)
+			if err != nil {
+				panic(err)
+			}
+			defer os.Remove("./load_test.csv")
+
+			c, _ := NewClient[Customer]("./load_test.csv")
+			r, e := Load(context.Background(), db, "customer", c, tc.ops...)
+			if fmt.Sprintf("%v", tc.expectedE) != fmt.Sprintf("%v", e) {
+				t.Errorf("must:%v, but got: %v", tc.expectedE, e)
+			}
+			if fmt.Sprintf("%v", tc.expectedR) != fmt.Sprintf("%v", r) {
+				t.Errorf("must:%v, but got: %v", tc.expectedR, r)
+			}
+			if tc.expectedQuery != store.queries[len(store.queries)-1] {
+				t.Errorf("must:%v, but got: %v", tc.expectedQuery, store.queries[len(store.queries)-1])
+			}
+			if tc.expectedCommits != fmt.Sprintf("%v", store.committed) {
+				t.Errorf("must:%v, but got: %v", tc.expectedCommits, store.committed)
+			}
+		})
+	}
+}
+
+func Test_Load_sliceAndMap(t *testing.T) {
+	type Product struct {
+		Name  string            `csv:"name"`
+		Tags  []string          `csv:"tags" split:";"`
+		Sizes []int             `csv:"sizes"`
+		Attrs map[string]string `csv:"attrs" split:";" kv:":"`
+		Dates []time.Time       `csv:"dates" split:"|" layout:"2006-01-02"`
+	}
+	db, err := sql.Open("csvtogo-fake", "")
+	if err != nil {
+		panic(err)
+	}
+	defer db.Close()
+	store.reset()
+	err = os.WriteFile("./load_slice_test.csv", []byte("name,tags,sizes,attrs,dates\nShirt,a;b,\"1,2\",size:M;color:red,2022-01-31|2022-02-01\nCap,,,,\n"), 0644)
+	if err != nil {
+		panic(err)
+	}
+	defer os.Remove("./load_slice_test.csv")
+
+	c, _ := NewClient[Product]("./load_slice_test.csv")
+	r, e := Load(context.Background(), db, "product", c)
+	if e != nil {
+		t.Fatalf("must:nil, but got: %v", e)
+	}
+	if fmt.Sprintf("%v", &LoadResult{Inserted: 2}) != fmt.Sprintf("%v", r) {
+		t.Errorf("must:%v, but got: %v", &LoadResult{Inserted: 2}, r)
+	}
+	expected := "[Shirt a;b 1,2 color:red;size:M 2022-01-31|2022-02-01 Cap    ]"
+	if expected != fmt.Sprintf("%v", store.committed) {
+		t.Errorf("must:%v, but got: %v", expected, store.committed)
+	}
+}
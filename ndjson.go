@@ -0,0 +1,221 @@
+package csvtogo
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+const (
+	FormatCSV    = "csv"
+	FormatNDJSON = "ndjson" //one JSON object per line, also known as JSON Lines
+	FormatXLSX   = "xlsx"   //Excel workbook, see Options.Sheet
+)
+
+// entryFormat return the format of entry, detect by file extension such as .jsonl, .ndjson.gz or .xlsx if format is empty
+func entryFormat(format, name string) string {
+	if format != "" {
+		return format
+	}
+	name = strings.ToLower(name)
+	if ext := path.Ext(name); ext == ".gz" || ext == ".bz2" || ext == ".zst" {
+		name = strings.TrimSuffix(name, ext)
+	}
+	switch path.Ext(name) {
+	case ".jsonl", ".ndjson":
+		return FormatNDJSON
+	case ".xlsx":
+		return FormatXLSX
+	}
+	return FormatCSV
+}
+
+// ndjsonReader read every JSON object line by line, the row is the line number start from 1.
+// The objects are set one by one, then the order of the rows is the same as the file.
+func ndjsonReader[T any](r io.Reader, ops Options, ref T, s setters[T]) error {
+	if s.json == nil {
+		return fmt.Errorf("ndjson is not support by the client")
+	}
+	//convert file content to UTF-8
+	src, err := newDecoder(r, ops.Encoding)
+	if err != nil {
+		return err
+	}
+
+	s.begin(FormatNDJSON)
+	br := bufio.NewReader(src)
+	row := 0
+	for {
+		line, err := br.ReadBytes('\n')
+		if err != nil && err != io.EOF {
+			return err
+		}
+		row += 1
+		if l := bytes.TrimSpace(line); len(l) > 0 {
+			ops.stats.row()
+			v, e := s.json(ref, l, row)
+			e = ops.skipInvalid(e)
+			if e == nil && v != nil {
+				e = s.send(v, row)
+			}
+			if e != nil {
+				return e
+			}
+		}
+		if err == io.EOF {
+			//no more content
+			return nil
+		}
+	}
+}
+
+// jsonSetter decode JSON object into ref, each key is mapped to the field like the header of csv
+// then the value is converted and validated the same way as csv column
+func (c *Executor[T]) jsonSetter(ref T, line []byte, row int) (*T, error) {
+	keys, values, err := jsonObject(line)
+	if err != nil {
+		return nil, rowError(row, "", "", fmt.Errorf("invalid json value at row: %v, %v", row, err))
+	}
+
+	t := reflect.TypeOf(ref)
+	fields := structFields(t)
+	if !equalKeys(c.keys, keys) {
+		c.keys = keys
+		c.columns = headerColumns(fields, keys)
+		if c.ops.Schema != nil {
+			c.applySchema(t, keys)
+		}
+	}
+
+	data := make([]string, len(values))
+	for i, raw := range values {
+		if i >= len(c.columns) || c.columns[i] < 0 {
+			//no field match with the key
+			continue
+		}
+		data[i], err = jsonText(raw, fields[c.columns[i]].field)
+		if err != nil {
+			return nil, rowError(row, keys[i], string(raw), fmt.Errorf("invalid json value at row: %v, %v", row, err))
+		}
+	}
+
+	//set value by using reflex, SkipCols is not used with JSON
+	err = c.setValues(data, &ref, row, nil)
+	if err != nil {
+		return nil, err
+	}
+	keep, err := c.postConvert(&ref, row)
+	if !keep {
+		return nil, err
+	}
+
+	//validate struct value from tag
+	err = validateValue(reflect.ValueOf(&ref).Elem(), row, c.rules)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ref, nil
+}
+
+// jsonObject return the keys and values of JSON object in order
+func jsonObject(line []byte) ([]string, []json.RawMessage, error) {
+	dec := json.NewDecoder(bytes.NewReader(line))
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, nil, err
+	}
+	if tok != json.Delim('{') {
+		return nil, nil, fmt.Errorf("the line is not JSON object")
+	}
+
+	var keys []string
+	var values []json.RawMessage
+	for dec.More() {
+		tok, err = dec.Token()
+		if err != nil {
+			return nil, nil, err
+		}
+		var raw json.RawMessage
+		err = dec.Decode(&raw)
+		if err != nil {
+			return nil, nil, err
+		}
+		keys = append(keys, tok.(string))
+		values = append(values, raw)
+	}
+	if _, err = dec.Token(); err != nil {
+		return nil, nil, err
+	}
+	if _, err = dec.Token(); err != io.EOF {
+		return nil, nil, fmt.Errorf("only one JSON object is allowed per line")
+	}
+	return keys, values, nil
+}
+
+// jsonText convert JSON value to the text of csv column, null is empty,
+// array and object are joined by split and kv tag of the field like slice and map column
+func jsonText(raw json.RawMessage, sf reflect.StructField) (string, error) {
+	switch raw[0] {
+	case 'n':
+		return "", nil
+	case '"':
+		var s string
+		err := json.Unmarshal(raw, &s)
+		return s, err
+	case '[':
+		var items []json.RawMessage
+		err := json.Unmarshal(raw, &items)
+		if err != nil {
+			return "", err
+		}
+		s := make([]string, len(items))
+		for i, item := range items {
+			s[i], err = jsonText(item, sf)
+			if err != nil {
+				return "", err
+			}
+		}
+		return strings.Join(s, splitTag(sf)), nil
+	case '{':
+		var m map[string]json.RawMessage
+		err := json.Unmarshal(raw, &m)
+		if err != nil {
+			return "", err
+		}
+		kv := sf.Tag.Get(tagKv)
+		if kv == "" {
+			kv = defaultKv
+		}
+		s := make([]string, 0, len(m))
+		for k, v := range m {
+			val, err := jsonText(v, sf)
+			if err != nil {
+				return "", err
+			}
+			s = append(s, k+kv+val)
+		}
+		sort.Strings(s)
+		return strings.Join(s, splitTag(sf)), nil
+	}
+	//number and bool
+	return string(raw), nil
+}
+
+func equalKeys(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,216 @@
+package csvtogo
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_NDJSON(t *testing.T) {
+	type Order struct {
+		ID       int       `csv:"id"`
+		Name     string    `csv:"name" min:"1" max:"5"`
+		Tags     []string  `csv:"tags" split:"|"`
+		Price    *float64  `csv:"price"`
+		CreateAt time.Time `csv:"create_at" layout:"2006-01-02"`
+		File     string    `source:"file"`
+	}
+	tt := []struct {
+		name      string
+		file      string
+		ops       []*Options
+		content   string
+		expectedR string
+		expectedE error
+	}{
+		{
+			name:      "should detect ndjson by extension and convert value like csv column",
+			file:      "./ndjson_test.jsonl",
+			content:   "{\"id\":1,\"NAME\":\"Sarah\",\"tags\":[\"a\",\"b\"],\"price\":10.5,\"create_at\":\"2022-01-31\",\"other\":{\"x\":1}}\n\n{\"name\":\"John\",\"id\":2,\"price\":null}\n",
+			expectedR: "[{1 Sarah [a b] 10.5 2022-01-31 ./ndjson_test.jsonl} {2 John [] <nil> 0001-01-01 ./ndjson_test.jsonl}]",
+		},
+		{
+			name:      "should read ndjson when Format is set",
+			file:      "./ndjson_test.txt",
+			ops:       []*Options{{Format: FormatNDJSON}},
+			content:   "{\"id\":1,\"name\":\"Sarah\"}",
+			expectedR: "[{1 Sarah [] <nil> 0001-01-01 ./ndjson_test.txt}]",
+		},
+		{
+			name:      "should validate value by struct tags",
+			file:      "./ndjson_test.ndjson",
+			content:   "{\"id\":1,\"name\":\"Sarah\"}\n{\"id\":2,\"name\":\"\"}\n",
+			expectedE: fmt.Errorf("value of Name at row 2 is invalid, value length must more than or equal 1, but got: 0"),
+		},
+		{
+			name:      "should validate value by schema",
+			file:      "./ndjson_test.ndjson",
+			ops:       []*Options{{Schema: &Schema{Columns: []*SchemaColumn{{Name: "name", OneOf: []string{"Sarah"}}}}}},
+			content:   "{\"id\":1,\"name\":\"Sarah\"}\n{\"id\":2,\"name\":\"John\"}\n",
+			expectedE: fmt.Errorf("value of Name at row 2 is invalid, value must be one of [Sarah], but got: John"),
+		},
+		{
+			name:      "should return error when value type is invalid",
+			file:      "./ndjson_test.ndjson",
+			content:   "{\"id\":\"x\",\"name\":\"Sarah\"}\n",
+			expectedE: fmt.Errorf("invalid csv value at row: 1, the struct accept type int"),
+		},
+		{
+			name:      "should return error when line is not JSON object",
+			file:      "./ndjson_test.ndjson",
+			content:   "{\"id\":1,\"name\":\"Sarah\"}\n[1]\n",
+			expectedE: fmt.Errorf("invalid json value at row: 2, the line is not JSON object"),
+		},
+		{
+			name:      "should return error when format is not support",
+			file:      "./ndjson_test.ndjson",
+			ops:       []*Options{{Format: "xml"}},
+			content:   "{}",
+			expectedE: fmt.Errorf("format xml is not support"),
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			err := os.WriteFile(tc.file, []byte(tc.content), 0644)
+			if err != nil {
+				panic(err)
+			}
+			defer os.Remove(tc.file)
+
+			c, e := NewClient[Order](tc.file, tc.ops...)
+			var r []*Order
+			if e == nil {
+				r, e = c.CsvToStruct()
+			}
+			if fmt.Sprintf("%v", tc.expectedE) != fmt.Sprintf("%v", e) {
+				t.Errorf("must:%v, but got: %v", tc.expectedE, e)
+			}
+			if tc.expectedE != nil {
+				return
+			}
+			var s []string
+			for _, o := range r {
+				price := "<nil>"
+				if o.Price != nil {
+					price = fmt.Sprintf("%v", *o.Price)
+				}
+				s = append(s, fmt.Sprintf("{%v %v %v %v %v %v}", o.ID, o.Name, o.Tags, price, o.CreateAt.Format("2006-01-02"), o.File))
+			}
+			if tc.expectedR != "["+strings.Join(s, " ")+"]" {
+				t.Errorf("must:%v, but got: %v", tc.expectedR, "["+strings.Join(s, " ")+"]")
+			}
+		})
+	}
+}
+
+func Test_NDJSON_mixedZip(t *testing.T) {
+	type Person struct {
+		Name string `csv:"name"`
+		Age  int    `csv:"age"`
+	}
+	genZip("./ndjson_mixed_test.zip", map[string]string{
+		"a.jsonl": "{\"name\":\"Ann\",\"age\":1}\n",
+		"b.csv":   "age,name\n2,Bob\n",
+		"c.jsonl": "{\"name\":\"Cat\",\"age\":3}\n",
+	}, []string{"a.jsonl", "b.csv", "c.jsonl"})
+	defer os.Remove("./ndjson_mixed_test.zip")
+
+	c, e := NewClient[Person]("./ndjson_mixed_test.zip")
+	var r []*Person
+	if e == nil {
+		r, e = c.CsvToStruct()
+	}
+	if e != nil {
+		t.Fatalf("must:nil, but got: %v", e)
+	}
+	var s []string
+	for _, p := range r {
+		s = append(s, fmt.Sprintf("%v", *p))
+	}
+	expected := "[{Ann 1} {Bob 2} {Cat 3}]"
+	if expected != "["+strings.Join(s, " ")+"]" {
+		t.Errorf("must:%v, but got: %v", expected, "["+strings.Join(s, " ")+"]")
+	}
+}
+
+func Test_entryFormat(t *testing.T) {
+	tt := []struct {
+		name      string
+		format    string
+		file      string
+		expectedR string
+	}{
+		{name: "should detect jsonl", file: "a.jsonl", expectedR: FormatNDJSON},
+		{name: "should detect compressed ndjson", file: "a.NDJSON.gz", expectedR: FormatNDJSON},
+		{name: "should be csv by default", file: "a.txt", expectedR: FormatCSV},
+		{name: "should use format of options", format: FormatCSV, file: "a.jsonl", expectedR: FormatCSV},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			r := entryFormat(tc.format, tc.file)
+			if tc.expectedR != r {
+				t.Errorf("must:%v, but got: %v", tc.expectedR, r)
+			}
+		})
+	}
+}
+
+func Test_NDJSON_dynamic(t *testing.T) {
+	tt := []struct {
+		name      string
+		ops       []*Options
+		content   string
+		expectedR string
+		expectedE error
+	}{
+		{
+			name:      "should read keys of each object as header",
+			content:   "{\"id\":1,\"name\":\" Sarah \",\"tags\":[\"a\",\"b\"]}\n\n{\"name\":\"John\",\"id\":null}\n",
+			ops:       []*Options{{TrimSpace: true}},
+			expectedR: "[1:map[id:1 name:Sarah tags:a,b] 3:map[id: name:John]]",
+		},
+		{
+			name: "should rename and validate by schema",
+			ops: []*Options{{Schema: &Schema{Columns: []*SchemaColumn{
+				{Name: "id", Field: "ID", Type: TypeInt},
+			}}}},
+			content:   "{\"id\":1}\n{\"id\":\"x\"}\n",
+			expectedE: fmt.Errorf("invalid csv value at row: 2, column id is not int"),
+		},
+		{
+			name:      "should return error when line is not JSON object",
+			content:   "[1]\n",
+			expectedE: fmt.Errorf("invalid json value at row: 1, the line is not JSON object"),
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			err := os.WriteFile("./ndjson_test.jsonl", []byte(tc.content), 0644)
+			if err != nil {
+				panic(err)
+			}
+			defer os.Remove("./ndjson_test.jsonl")
+
+			c, e := NewDynamicClient("./ndjson_test.jsonl", tc.ops...)
+			var r []*Record
+			if e == nil {
+				r, e = c.CsvToStruct()
+			}
+			if fmt.Sprintf("%v", tc.expectedE) != fmt.Sprintf("%v", e) {
+				t.Errorf("must:%v, but got: %v", tc.expectedE, e)
+			}
+			if tc.expectedE != nil {
+				return
+			}
+			var s []string
+			for _, rec := range r {
+				s = append(s, fmt.Sprintf("%v:%v", rec.Row(), rec.Map()))
+			}
+			if tc.expectedR != "["+strings.Join(s, " ")+"]" {
+				t.Errorf("must:%v, but got: %v", tc.expectedR, "["+strings.Join(s, " ")+"]")
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package csvtogo
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+)
+
+func Test_Normalize(t *testing.T) {
+	type Product struct {
+		Code  string  `csv:"code" trim:"true" case:"upper" max:"3"`
+		Price float64 `csv:"price" strip:",$"`
+		Note  string  `csv:"note"`
+	}
+	type Raw struct {
+		Code string `csv:"code" trim:"false"`
+		Note string `csv:"note"`
+	}
+	type List struct {
+		Nums  []int          `csv:"nums" split:";"`
+		Attrs map[string]int `csv:"attrs" split:";"`
+		Raw   []string       `csv:"raw" split:";" trim:"false"`
+	}
+	type Invalid struct {
+		Code string `csv:"code" case:"title"`
+	}
+	tt := []struct {
+		name      string
+		ops       *Options
+		content   string
+		read      func(string, *Options) ([]string, error)
+		expectedR string
+		expectedE error
+	}{
+		{
+			name:      "should trim, strip and change case before conversion",
+			ops:       &Options{SkipHeader: true},
+			content:   "code,price,note\n abc ,\"$1,000.50\", keep \n",
+			read:      readNormalize[Product],
+			expectedR: "[{ABC 1000.5  keep }]",
+		},
+		{
+			name:      "should validate value after trim",
+			ops:       &Options{SkipHeader: true},
+			content:   "code,price,note\n  abcd  ,1,\n",
+			read:      readNormalize[Product],
+			expectedE: errors.New("value of Code at row 1 is invalid, value length must less than or equal 3, but got: 4"),
+		},
+		{
+			name:      "should trim every value by TrimSpace but trim tag",
+			ops:       &Options{SkipHeader: true, TrimSpace: true},
+			content:   "code,note\n a , b \n",
+			read:      readNormalize[Raw],
+			expectedR: "[{ a  b}]",
+		},
+		{
+			name:      "should trim each element of slice and map by TrimSpace but trim tag",
+			ops:       &Options{SkipHeader: true, TrimSpace: true},
+			content:   "nums,attrs,raw\n1; 2 ,a = 1; b=2,x; y\n",
+			read:      readNormalize[List],
+			expectedR: "[{[1 2] map[a:1 b:2] [x  y]}]",
+		},
+		{
+			name:      "should return error when case tag is invalid",
+			ops:       &Options{SkipHeader: true},
+			content:   "code\nabc\n",
+			read:      readNormalize[Invalid],
+			expectedE: errors.New("tag case of field Code must be upper or lower, got: title"),
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			err := os.WriteFile("./normalize_test.csv", []byte(tc.content), 0644)
+			if err != nil {
+				panic(err)
+			}
+			defer os.Remove("./normalize_test.csv")
+
+			r, e := tc.read("./normalize_test.csv", tc.ops)
+			if fmt.Sprintf("%v", tc.expectedE) != fmt.Sprintf("%v", e) {
+				t.Errorf("must:%v, but got: %v", tc.expectedE, e)
+			}
+			if tc.expectedE != nil {
+				return
+			}
+			if tc.expectedR != "["+strings.Join(r, " ")+"]" {
+				t.Errorf("must:%v, but got: %v", tc.expectedR, r)
+			}
+		})
+	}
+}
+
+func readNormalize[T any](file string, ops *Options) ([]string, error) {
+	c, err := NewClient[T](file, ops)
+	if err != nil {
+		return nil, err
+	}
+	r, err := c.CsvToStruct()
+	if err != nil {
+		return nil, err
+	}
+	var s []string
+	for _, v := range r {
+		s = append(s, fmt.Sprintf("%v", *v))
+	}
+	return s, nil
+}
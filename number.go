@@ -0,0 +1,164 @@
+package csvtogo
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+const tagNumber = "number"
+
+const (
+	NumberEN = "en" //1,234.50
+	NumberEU = "eu" //1.234,50
+	NumberFR = "fr" //1 234,50
+	NumberCH = "ch" //1'234.50
+)
+
+// numberMarks is the grouping separators and decimal mark of each number format
+var numberMarks = map[string]struct {
+	group   string
+	decimal rune
+}{
+	NumberEN: {group: ",", decimal: '.'},
+	NumberEU: {group: ".", decimal: ','},
+	NumberFR: {group: " \u00a0\u202f", decimal: ','},
+	NumberCH: {group: "'’", decimal: '.'},
+}
+
+// valueFormat is the default format of Options that used when the field has no tag
+type valueFormat struct {
+	number string //Options.NumberFormat
+	bool   string //Options.BoolFormat
+	trim   bool   //Options.TrimSpace
+	xlsx   bool   //the value is read from xlsx, the date cell is RFC3339 whatever the layout tag
+}
+
+func (o Options) valueFormat() valueFormat {
+	return valueFormat{number: o.NumberFormat, bool: o.BoolFormat, trim: o.TrimSpace}
+}
+
+func validNumberFormat(format string) error {
+	if _, ok := numberMarks[format]; !ok && format != "" {
+		return fmt.Errorf("number format %v is not support", format)
+	}
+	return nil
+}
+
+// isNumber return true if the value of f is converted by ParseFloat or Atoi
+func isNumber(f reflect.Value) bool {
+	if f.Type() == durationType {
+		return false
+	}
+	switch f.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// parseNumber return the number in Go syntax such as -1234.5, the currency symbol and grouping separators are removed,
+// (1,234.50) is negative and 12% is 0.12. The grouping separator is accepted only between groups of three digits before
+// the decimal mark, the value that is not a number in the format is returned as is, then typeSafe reject it
+func parseNumber(val, format string) (string, error) {
+	marks, ok := numberMarks[format]
+	if !ok {
+		return "", fmt.Errorf("number format %v is not support", format)
+	}
+	s := strings.TrimSpace(val)
+	neg := strings.HasPrefix(s, "(") && strings.HasSuffix(s, ")")
+	if neg {
+		s = s[1 : len(s)-1]
+	}
+	percent := strings.HasSuffix(s, "%")
+	if percent {
+		s = s[:len(s)-1]
+	}
+
+	//remove currency symbol
+	s = strings.TrimSpace(strings.Map(func(r rune) rune {
+		if unicode.Is(unicode.Sc, r) {
+			return -1
+		}
+		return r
+	}, s))
+	sign := ""
+	if strings.HasPrefix(s, "-") || strings.HasPrefix(s, "+") {
+		if neg {
+			return val, nil
+		}
+		sign, s = s[:1], strings.TrimSpace(s[1:])
+	}
+	if neg {
+		sign = "-"
+	}
+	i, f, hasDecimal := strings.Cut(s, string(marks.decimal))
+	if strings.ContainsRune(f, marks.decimal) || strings.ContainsAny(f, marks.group) {
+		//more than one decimal mark or grouping separator after it
+		return val, nil
+	}
+	i, ok = ungroup(i, marks.group)
+	if !ok {
+		return val, nil
+	}
+	s = sign + i
+	if hasDecimal {
+		s += "." + f
+	}
+	if percent {
+		if _, err := strconv.ParseFloat(s, 64); err != nil {
+			return val, nil
+		}
+		s = shiftPercent(s)
+	}
+	return s, nil
+}
+
+// ungroup remove the grouping separators of the integer part such as 1,234,567, ok is false when some group is not
+// three digits except the first one
+func ungroup(s, group string) (string, bool) {
+	if !strings.ContainsAny(s, group) {
+		return s, true
+	}
+	var b strings.Builder
+	n, first := 0, true
+	for _, r := range s {
+		switch {
+		case strings.ContainsRune(group, r):
+			if n == 0 || n > 3 || (!first && n != 3) {
+				return "", false
+			}
+			n, first = 0, false
+		case r >= '0' && r <= '9':
+			b.WriteRune(r)
+			n++
+		default:
+			return "", false
+		}
+	}
+	return b.String(), n == 3
+}
+
+// shiftPercent move the decimal point of s two digits to the left without float rounding such as 12.5 to 0.125
+func shiftPercent(s string) string {
+	sign := ""
+	if strings.HasPrefix(s, "-") || strings.HasPrefix(s, "+") {
+		sign, s = s[:1], s[1:]
+	}
+	i, f, _ := strings.Cut(s, ".")
+	if len(i) < 3 {
+		i = strings.Repeat("0", 3-len(i)) + i
+	}
+	i, f = strings.TrimLeft(i[:len(i)-2], "0"), strings.TrimRight(i[len(i)-2:]+f, "0")
+	if i == "" {
+		i = "0"
+	}
+	if f == "" {
+		return sign + i
+	}
+	return sign + i + "." + f
+}
@@ -0,0 +1,124 @@
+package csvtogo
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+)
+
+func Test_parseNumber(t *testing.T) {
+	tt := []struct {
+		name      string
+		val       string
+		format    string
+		expectedR string
+		expectedE error
+	}{
+		{name: "should remove grouping separator", val: "1,234.50", format: NumberEN, expectedR: "1234.50"},
+		{name: "should replace decimal comma", val: "1.234,50", format: NumberEU, expectedR: "1234.50"},
+		{name: "should remove space and no-break space", val: "1 234 567,5", format: NumberFR, expectedR: "1234567.5"},
+		{name: "should remove apostrophe", val: "1'234.5", format: NumberCH, expectedR: "1234.5"},
+		{name: "should remove currency symbol", val: "฿1,000", format: NumberEN, expectedR: "1000"},
+		{name: "should keep minus sign with currency", val: "-€1.000,25", format: NumberEU, expectedR: "-1000.25"},
+		{name: "should be negative in parentheses", val: "($1,234.50)", format: NumberEN, expectedR: "-1234.50"},
+		{name: "should scale percentage", val: "12%", format: NumberEN, expectedR: "0.12"},
+		{name: "should scale percentage without rounding", val: "-12,3 %", format: NumberEU, expectedR: "-0.123"},
+		{name: "should scale big percentage", val: "1,250%", format: NumberEN, expectedR: "12.5"},
+		{name: "should return value as is when it's not a number", val: "abc%", format: NumberEN, expectedR: "abc%"},
+		{name: "should return value as is when decimal mark is grouping separator", val: "1,234.50", format: NumberEU, expectedR: "1,234.50"},
+		{name: "should return value as is when group is not three digits", val: "1.5", format: NumberEU, expectedR: "1.5"},
+		{name: "should return value as is when grouping separator is after decimal mark", val: "1.234,50", format: NumberEN, expectedR: "1.234,50"},
+		{name: "should return value as is when groups are single digit", val: "1,2,3", format: NumberEN, expectedR: "1,2,3"},
+		{name: "should return value as is when there are two decimal marks", val: "1.2.3", format: NumberEN, expectedR: "1.2.3"},
+		{name: "should return value as is when group is empty", val: "1,,234", format: NumberEN, expectedR: "1,,234"},
+		{name: "should return value as is when first group is over three digits", val: "1234,567", format: NumberEN, expectedR: "1234,567"},
+		{name: "should keep number without grouping separator", val: "1234,5", format: NumberEU, expectedR: "1234.5"},
+		{name: "should return error when format is not support", val: "1", format: "jp", expectedE: errors.New("number format jp is not support")},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			r, e := parseNumber(tc.val, tc.format)
+			if fmt.Sprintf("%v", tc.expectedE) != fmt.Sprintf("%v", e) {
+				t.Errorf("must:%v, but got: %v", tc.expectedE, e)
+			}
+			if tc.expectedR != r {
+				t.Errorf("must:%v, but got: %v", tc.expectedR, r)
+			}
+		})
+	}
+}
+
+func Test_NumberFormat(t *testing.T) {
+	type Sale struct {
+		Amount   float64   `csv:"amount"`
+		Qty      int       `csv:"qty"`
+		Discount float64   `csv:"discount" number:"en"`
+		Prices   []float64 `csv:"prices" split:";"`
+	}
+	tt := []struct {
+		name      string
+		ops       *Options
+		content   string
+		expectedR string
+		expectedE error
+	}{
+		{
+			name:      "should parse number by NumberFormat and number tag",
+			ops:       &Options{SkipHeader: true, NumberFormat: NumberEU},
+			content:   "amount,qty,discount,prices\n\"1.234,50\",\"1.000\",12.5%,\"1,5;€2\"\n(10),5,0%,\n",
+			expectedR: "[{1234.5 1000 0.125 [1.5 2]} {-10 5 0 []}]",
+		},
+		{
+			name:      "should parse number by number tag only",
+			ops:       &Options{SkipHeader: true},
+			content:   "amount,qty,discount,prices\n1.5,1,\"$1,000\",\n",
+			expectedR: "[{1.5 1 1000 []}]",
+		},
+		{
+			name:      "should return error when number is in other format",
+			ops:       &Options{SkipHeader: true, NumberFormat: NumberEU},
+			content:   "amount,qty,discount,prices\n\"1,234.50\",1,0,\n",
+			expectedE: errors.New("invalid csv value at row: 1, the struct accept type float"),
+		},
+		{
+			name:      "should return error when value is not a number",
+			ops:       &Options{SkipHeader: true, NumberFormat: NumberEN},
+			content:   "amount,qty,discount,prices\n1,5%,0,\n",
+			expectedE: errors.New("invalid csv value at row: 1, the struct accept type int"),
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			err := os.WriteFile("./number_test.csv", []byte(tc.content), 0644)
+			if err != nil {
+				panic(err)
+			}
+			defer os.Remove("./number_test.csv")
+
+			c, _ := NewClient[Sale]("./number_test.csv", tc.ops)
+			r, e := c.CsvToStruct()
+			if fmt.Sprintf("%v", tc.expectedE) != fmt.Sprintf("%v", e) {
+				t.Errorf("must:%v, but got: %v", tc.expectedE, e)
+			}
+			if tc.expectedE != nil {
+				return
+			}
+			var s []string
+			for _, v := range r {
+				s = append(s, fmt.Sprintf("%v", *v))
+			}
+			if tc.expectedR != "["+strings.Join(s, " ")+"]" {
+				t.Errorf("must:%v, but got: %v", tc.expectedR, s)
+			}
+		})
+	}
+
+	t.Run("should return error when NumberFormat is not support", func(t *testing.T) {
+		_, e := NewClient[Sale]("./number_test.csv", &Options{NumberFormat: "jp"})
+		if fmt.Sprintf("%v", e) != "number format jp is not support" {
+			t.Errorf("must:%v, but got: %v", "number format jp is not support", e)
+		}
+	})
+}
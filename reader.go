@@ -2,64 +2,188 @@ package csvtogo
 
 import (
 	"encoding/csv"
+	"errors"
+	"fmt"
 	"io"
-	"os"
-	"sync"
+	"io/fs"
+	"strings"
 )
 
-func csvReader[T any](csvFile string, comma rune, valueSetter func(T, []string, int) error) error {
-	f, err := os.Open(csvFile)
+// inFlight is the number of rows that converted concurrently
+const inFlight = 10
+
+// setters convert each row into T then send it to client, the value is nil when the row is skipped such as header or filtered
+type setters[T any] struct {
+	value func(T, []string, int) (*T, error)
+	json  func(T, []byte, int) (*T, error) //nil if NDJSON is not supported
+	send  func(*T, int) error
+	begin func(string) //called with the format before each entry, the mapping of the previous entry is forgotten
+}
+
+// rowResult is the converted row that waiting to send
+type rowResult[T any] struct {
+	value *T
+	row   int
+	err   error
+}
+
+func fileReader[T any](fsys fs.FS, csvFile string, ops Options, s setters[T]) error {
+	f, err := openFile(fsys, csvFile)
 	if err != nil {
 		return err
 	}
 	defer f.Close()
+	return streamReader[T](f, csvFile, ops, s)
+}
+
+func streamReader[T any](r io.Reader, name string, ops Options, s setters[T]) error {
+	//the template of each row, carry the source file name
+	var ref T
+	setSource(&ref, name)
+	if entryFormat(ops.Format, name) == FormatXLSX {
+		//xlsx is zip archive, must be detected before decompress
+		return xlsxReader[T](r, ops, ref, s)
+	}
+
+	//decompress file if needed, zip archive may contain more than one csv
+	entries, err := openEntries(r, name, ops.ZipEntry)
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		err = e.read(func(r io.Reader) error {
+			if entryFormat(ops.Format, e.name) == FormatNDJSON {
+				return ndjsonReader[T](r, ops, ref, s)
+			}
+			return csvReader[T](r, ops, ref, s)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
 
-	var d []string
-	var wg sync.WaitGroup
-	var dChan = make(chan bool, 1)
-	var pool = make(chan bool, 10)
-	var chanErr = make(chan error, 1)
-	defer close(pool)
-	defer close(chanErr)
+// csvReader convert the rows concurrently, then send them in the order of the file.
+// The error of the first invalid row is returned, the rows after it are not sent.
+func csvReader[T any](r io.Reader, ops Options, ref T, s setters[T]) error {
+	//convert file content to UTF-8
+	src, err := newDecoder(r, ops.Encoding)
+	if err != nil {
+		return err
+	}
+	s.begin(FormatCSV)
 
-	reader := csv.NewReader(f)
-	reader.Comma = comma
+	//the result of each row in the order of the file, the size limit the running rows
+	results := make(chan chan rowResult[T], inFlight)
+	stop := make(chan struct{})
+	done := make(chan error, 1)
+	go sendInOrder(results, s.send, ops.skipInvalid, stop, done)
+	wait := func(err error) error {
+		close(results)
+		if e := <-done; e != nil {
+			//the invalid row is before the error
+			return e
+		}
+		return err
+	}
+
+	reader := newCsvReader(src, ops)
 	row := -1
-	ref := make([]T, 1)
 	for {
+		select {
+		case <-stop:
+			//some row is failed
+			return wait(nil)
+		default:
+		}
 		row += 1
-		d, err = reader.Read()
+		d, err := reader.Read()
 		if err == io.EOF {
 			//no more content
-			break
+			return wait(nil)
+		}
+		var pe *csv.ParseError
+		if err != nil && ops.invalidRow != nil && errors.As(err, &pe) {
+			//report the row in the order of the file then continue, the reader is still usable after parse error
+			res := make(chan rowResult[T], 1)
+			res <- rowResult[T]{row: row, err: &RowError{Row: row, Value: strings.Join(d, string(reader.Comma)), Err: parseError(pe, reader.FieldsPerRecord, len(d), row)}}
+			results <- res
+			continue
+		}
+		if err != nil {
+			return wait(err)
+		}
+		if ops.SkipHeader && row == 0 {
+			//header must be done before any row, the column mapping may depend on it
+			_, err = s.value(ref, d, row)
+			if err != nil {
+				return wait(err)
+			}
+			continue
+		}
+		if !ops.keepRaw(d) {
+			//rejected before conversion
+			continue
 		}
-		pool <- true
-		wg.Add(1)
-		go asyncSet[T](valueSetter, ref[0], &wg, pool, chanErr, d, row)
+		res := make(chan rowResult[T], 1)
+		results <- res
+		go func(d []string, row int) {
+			v, err := s.value(ref, d, row)
+			res <- rowResult[T]{value: v, row: row, err: err}
+		}(d, row)
 	}
+}
 
-	go w8(&wg, &dChan)
+// sendInOrder send the result of each row in the order of results, stop is closed at the first error
+// then the running rows are drained until results is closed
+func sendInOrder[T any](results chan chan rowResult[T], send func(*T, int) error, skip func(error) error, stop chan struct{}, done chan error) {
+	var err error
+	for res := range results {
+		r := <-res
+		if err != nil {
+			continue
+		}
+		switch {
+		case r.err != nil:
+			err = skip(r.err)
+		case r.value != nil:
+			err = send(r.value, r.row)
+		}
+		if err != nil {
+			close(stop)
+		}
+	}
+	done <- err
+}
 
-	select {
-	case <-dChan:
-		return io.EOF
-	case e := <-chanErr:
-		return e
+// skipInvalid report the RowError to invalidRow then return nil, the reader continue with the next row.
+// Other errors are returned as is
+func (o Options) skipInvalid(err error) error {
+	var re *RowError
+	if o.invalidRow != nil && errors.As(err, &re) {
+		o.invalidRow(re)
+		return nil
 	}
+	return err
 }
 
-func w8(wg *sync.WaitGroup, dChan *chan bool) {
-	wg.Wait()
-	close(*dChan)
+// parseError return the error of the row that can't be parsed, expected is the number of fields of the reader
+func parseError(pe *csv.ParseError, expected, got, row int) error {
+	if errors.Is(pe, csv.ErrFieldCount) {
+		return fmt.Errorf("number of column is not match at row: %v, expected: %v, got: %v", row, expected, got)
+	}
+	return fmt.Errorf("invalid csv at row: %v, %v", row, pe.Err)
 }
 
-func asyncSet[T any](valueSetter func(T, []string, int) error, ref T, wg *sync.WaitGroup, pool chan bool, chanErr chan error, d []string, row int) {
-	defer func() {
-		wg.Done()
-		<-pool //release
-	}()
-	err := valueSetter(ref, d, row)
-	if err != nil {
-		chanErr <- err
+func newCsvReader(r io.Reader, ops Options) *csv.Reader {
+	reader := csv.NewReader(r)
+	if ops.Comma != 0 {
+		reader.Comma = ops.Comma
 	}
+	reader.Comment = ops.Comment
+	reader.LazyQuotes = ops.LazyQuotes
+	reader.TrimLeadingSpace = ops.TrimLeadingSpace
+	reader.FieldsPerRecord = ops.FieldsPerRecord
+	return reader
 }
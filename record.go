@@ -0,0 +1,298 @@
+package csvtogo
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Record is a row of dynamic client, the values are ordered by header
+type Record struct {
+	header []string
+	index  map[string]int
+	values []string
+	row    int
+}
+
+// NewDynamicClient read the file without struct, each row is returned as Record.
+// The header row is used as the name of each column when SkipHeader is true, otherwise the name is the column index.
+func NewDynamicClient(file string, ops ...*Options) (*Client[Record], error) {
+	c, err := newClient[Record](nil, []string{file}, false, ops...)
+	if err != nil {
+		return nil, err
+	}
+	c.setter, c.setJSON = recordSetter(&c.Executor), recordJSONSetter(&c.Executor)
+	return c, nil
+}
+
+// NewDynamicClientReader same as NewDynamicClient but read csv from r
+func NewDynamicClientReader(r io.Reader, ops ...*Options) (*Client[Record], error) {
+	c, err := NewClientReader[Record](r, ops...)
+	if err != nil {
+		return nil, err
+	}
+	c.setter, c.setJSON = recordSetter(&c.Executor), recordJSONSetter(&c.Executor)
+	return c, nil
+}
+
+func recordSetter(c *Executor[Record]) func(Record, []string, int) (*Record, error) {
+	var raw, header []string
+	var index map[string]int
+	return func(_ Record, data []string, row int) (*Record, error) {
+		if c.ops.SkipHeader && row == 0 {
+			//header is done before any row, see csvReader
+			raw = data
+			header = c.withoutSkipCols(renameColumns(c.ops.Schema, data))
+			index = headerIndex(header)
+			return nil, nil
+		}
+
+		data, err := c.ops.preTransform(data, row)
+		if err != nil {
+			return nil, err
+		}
+		if c.ops.TrimSpace {
+			for i := range data {
+				data[i] = strings.TrimSpace(data[i])
+			}
+		}
+		values := c.withoutSkipCols(data)
+		h, idx := header, index
+		if len(h) < len(values) {
+			//no header or variable fields
+			h = columnNames(h, len(values))
+			idx = headerIndex(h)
+		}
+		r := &Record{
+			header: h[:len(values)],
+			index:  idx,
+			values: values,
+			row:    row,
+		}
+		keep, err := c.postConvert(r, row)
+		if !keep {
+			return nil, err
+		}
+		if c.ops.Schema != nil {
+			data, err = validateRecord(c.ops.Schema, raw, data, row)
+			if err != nil {
+				return nil, err
+			}
+			r.values = c.withoutSkipCols(data)
+		}
+		return r, nil
+	}
+}
+
+// recordJSONSetter decode NDJSON object into Record, the keys of each object are the header of the record
+func recordJSONSetter(c *Executor[Record]) func(Record, []byte, int) (*Record, error) {
+	return func(_ Record, line []byte, row int) (*Record, error) {
+		keys, raws, err := jsonObject(line)
+		if err != nil {
+			return nil, rowError(row, "", "", fmt.Errorf("invalid json value at row: %v, %v", row, err))
+		}
+		data := make([]string, len(raws))
+		for i, raw := range raws {
+			//array and object are joined like slice and map column
+			data[i], err = jsonText(raw, reflect.StructField{})
+			if err != nil {
+				return nil, rowError(row, keys[i], string(raw), fmt.Errorf("invalid json value at row: %v, %v", row, err))
+			}
+			if c.ops.TrimSpace {
+				data[i] = strings.TrimSpace(data[i])
+			}
+		}
+		header := renameColumns(c.ops.Schema, keys)
+		r := &Record{
+			header: header,
+			index:  headerIndex(header),
+			values: data,
+			row:    row,
+		}
+		keep, err := c.postConvert(r, row)
+		if !keep {
+			return nil, err
+		}
+		if c.ops.Schema != nil {
+			r.values, err = validateRecord(c.ops.Schema, keys, data, row)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return r, nil
+	}
+}
+
+// renameColumns rename the header of dynamic record by Field of schema column
+func renameColumns(s *Schema, header []string) []string {
+	r := append([]string{}, header...)
+	if s == nil {
+		return r
+	}
+	for _, sc := range s.Columns {
+		if i := sc.column(header); sc.Field != "" && i >= 0 && i < len(r) {
+			r[i] = sc.Field
+		}
+	}
+	return r
+}
+
+// validateRecord replace the null value with empty and validate each column of schema
+func validateRecord(s *Schema, header, data []string, row int) ([]string, error) {
+	r := append([]string{}, data...)
+	for _, sc := range s.Columns {
+		i := sc.column(header)
+		if i < 0 || i >= len(r) {
+			continue
+		}
+		if sc.isNull(r[i]) {
+			r[i] = ""
+		}
+		err := sc.checkType(sc.label(), r[i], row)
+		if err == nil {
+			err = sc.check(sc.label(), r[i], row)
+		}
+		if err != nil {
+			return nil, rowError(row, sc.label(), data[i], err)
+		}
+	}
+	return r, nil
+}
+
+func (c *Executor[T]) withoutSkipCols(data []string) []string {
+	r := make([]string, 0, len(data))
+	for i, val := range data {
+		if _, ok := c.ops.skipper[i]; ok {
+			continue
+		}
+		r = append(r, val)
+	}
+	return r
+}
+
+// columnNames fill the missing header with column index
+func columnNames(header []string, size int) []string {
+	r := make([]string, size)
+	copy(r, header)
+	for i := len(header); i < size; i++ {
+		r[i] = strconv.Itoa(i)
+	}
+	return r
+}
+
+func headerIndex(header []string) map[string]int {
+	m := make(map[string]int)
+	for i, h := range header {
+		if _, ok := m[h]; !ok {
+			//keep the first column when header is duplicated
+			m[h] = i
+		}
+	}
+	return m
+}
+
+// Header return the column names in order
+func (r Record) Header() []string {
+	return r.header
+}
+
+// Values return the values in order
+func (r Record) Values() []string {
+	return r.values
+}
+
+// Row return the row number of record in csv file
+func (r Record) Row() int {
+	return r.row
+}
+
+// Len return number of columns
+func (r Record) Len() int {
+	return len(r.values)
+}
+
+// Map return the record as map of header and value
+func (r Record) Map() map[string]string {
+	m := make(map[string]string, len(r.values))
+	for i, h := range r.header {
+		if _, ok := m[h]; !ok {
+			m[h] = r.values[i]
+		}
+	}
+	return m
+}
+
+// Get return the value of column name, ok is false when column is not found
+func (r Record) Get(name string) (string, bool) {
+	i, ok := r.index[name]
+	if !ok || i >= len(r.values) {
+		return "", false
+	}
+	return r.values[i], true
+}
+
+// GetString return the value of column name, empty string if column is not found
+func (r Record) GetString(name string) string {
+	v, _ := r.Get(name)
+	return v
+}
+
+func (r Record) GetInt(name string) (int, error) {
+	v, err := r.value(name)
+	if err != nil {
+		return 0, err
+	}
+	i, err := strconv.Atoi(strings.TrimSpace(v))
+	if err != nil {
+		return 0, fmt.Errorf("invalid csv value at row: %v, column %v is not int", r.row, name)
+	}
+	return i, nil
+}
+
+func (r Record) GetFloat(name string) (float64, error) {
+	v, err := r.value(name)
+	if err != nil {
+		return 0, err
+	}
+	f, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid csv value at row: %v, column %v is not float", r.row, name)
+	}
+	return f, nil
+}
+
+func (r Record) GetBool(name string) (bool, error) {
+	v, err := r.value(name)
+	if err != nil {
+		return false, err
+	}
+	b, err := strconv.ParseBool(strings.TrimSpace(v))
+	if err != nil {
+		return false, fmt.Errorf("invalid csv value at row: %v, column %v is not bool", r.row, name)
+	}
+	return b, nil
+}
+
+// GetTime parse the value of column name with layout such as time.RFC3339
+func (r Record) GetTime(name, layout string) (time.Time, error) {
+	v, err := r.value(name)
+	if err != nil {
+		return time.Time{}, err
+	}
+	t, err := time.Parse(layout, strings.TrimSpace(v))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid csv value at row: %v, column %v is not time with layout %v", r.row, name, layout)
+	}
+	return t, nil
+}
+
+func (r Record) value(name string) (string, error) {
+	v, ok := r.Get(name)
+	if !ok {
+		return "", fmt.Errorf("column %v is not found", name)
+	}
+	return v, nil
+}
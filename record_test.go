@@ -0,0 +1,114 @@
+package csvtogo
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+)
+
+func Test_NewDynamicClient(t *testing.T) {
+	tt := []struct {
+		name      string
+		ops       []*Options
+		content   string
+		expectedR []map[string]string
+		expectedE error
+	}{
+		{
+			name:    "should return record by header and skip columns",
+			ops:     []*Options{{SkipHeader: true, Comma: ';', SkipCols: []int{0}}},
+			content: "ID;NAME;AGE\n1;Sarah;12\n2;John;21\n",
+			expectedR: []map[string]string{
+				{"NAME": "Sarah", "AGE": "12"},
+				{"NAME": "John", "AGE": "21"},
+			},
+			expectedE: nil,
+		},
+		{
+			name:    "should use column index as name when SkipHeader is false",
+			ops:     []*Options{{SkipHeader: false}},
+			content: "Sarah,12\n",
+			expectedR: []map[string]string{
+				{"0": "Sarah", "1": "12"},
+			},
+			expectedE: nil,
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			err := os.WriteFile("./dynamic_test.csv", []byte(tc.content), 0644)
+			if err != nil {
+				panic(err)
+			}
+			defer os.Remove("./dynamic_test.csv")
+
+			c, _ := NewDynamicClient("./dynamic_test.csv", tc.ops...)
+			r, e := c.CsvToStruct()
+			if fmt.Sprintf("%v", tc.expectedE) != fmt.Sprintf("%v", e) {
+				t.Errorf("must:%v, but got: %v", tc.expectedE, e)
+			}
+			if len(tc.expectedR) != len(r) {
+				t.Fatalf("must:%v, but got: %v", len(tc.expectedR), len(r))
+			}
+			for i, val := range r {
+				if fmt.Sprintf("%v", tc.expectedR[i]) != fmt.Sprintf("%v", val.Map()) {
+					t.Errorf("must:%v, but got: %v", tc.expectedR[i], val.Map())
+				}
+			}
+		})
+	}
+}
+
+func Test_Record(t *testing.T) {
+	header := []string{"NAME", "AGE", "SALARY", "MARRIED", "CREATE_AT"}
+	r := Record{
+		header: header,
+		index:  headerIndex(header),
+		values: []string{"Sarah", "12", "10.5", "true", "2022-01-31"},
+		row:    1,
+	}
+
+	t.Run("should return value when column is found", func(t *testing.T) {
+		v, ok := r.Get("NAME")
+		if !ok || v != "Sarah" {
+			t.Errorf("must:Sarah, but got: %v", v)
+		}
+		if r.GetString("OTHER") != "" {
+			t.Errorf("must: empty, but got: %v", r.GetString("OTHER"))
+		}
+	})
+
+	t.Run("should return typed value", func(t *testing.T) {
+		i, err := r.GetInt("AGE")
+		if err != nil || i != 12 {
+			t.Errorf("must:12, but got: %v, %v", i, err)
+		}
+		f, err := r.GetFloat("SALARY")
+		if err != nil || f != 10.5 {
+			t.Errorf("must:10.5, but got: %v, %v", f, err)
+		}
+		b, err := r.GetBool("MARRIED")
+		if err != nil || !b {
+			t.Errorf("must:true, but got: %v, %v", b, err)
+		}
+		tm, err := r.GetTime("CREATE_AT", "2006-01-02")
+		if err != nil || !tm.Equal(time.Date(2022, 1, 31, 0, 0, 0, 0, time.UTC)) {
+			t.Errorf("must:2022-01-31, but got: %v, %v", tm, err)
+		}
+	})
+
+	t.Run("should return err when value is invalid", func(t *testing.T) {
+		_, err := r.GetInt("NAME")
+		expectedE := errors.New("invalid csv value at row: 1, column NAME is not int")
+		if fmt.Sprintf("%v", expectedE) != fmt.Sprintf("%v", err) {
+			t.Errorf("must:%v, but got: %v", expectedE, err)
+		}
+		_, err = r.GetBool("OTHER")
+		expectedE = errors.New("column OTHER is not found")
+		if fmt.Sprintf("%v", expectedE) != fmt.Sprintf("%v", err) {
+			t.Errorf("must:%v, but got: %v", expectedE, err)
+		}
+	})
+}
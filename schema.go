@@ -0,0 +1,273 @@
+package csvtogo
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Schema define the column mapping and validation rules outside the code, see LoadSchema
+type Schema struct {
+	Columns []*SchemaColumn `json:"columns" yaml:"columns"`
+}
+
+// SchemaColumn is the rules of a column, the column is found by Name (header) or Index.
+// The rules are added to the struct tags, Min and Max override the min / max tag of the field.
+type SchemaColumn struct {
+	Name    string   `json:"name,omitempty" yaml:"name,omitempty"`
+	Index   *int     `json:"index,omitempty" yaml:"index,omitempty"`
+	Field   string   `json:"field,omitempty" yaml:"field,omitempty"` //struct field such as Home.City, or the name of dynamic record column
+	Type    string   `json:"type,omitempty" yaml:"type,omitempty"`   //int, float, bool, time or string, validate value of dynamic record
+	Layout  string   `json:"layout,omitempty" yaml:"layout,omitempty"`
+	Null    []string `json:"null,omitempty" yaml:"null,omitempty"` //the value that treat as empty such as N/A
+	Min     *int     `json:"min,omitempty" yaml:"min,omitempty"`
+	Max     *int     `json:"max,omitempty" yaml:"max,omitempty"`
+	Pattern string   `json:"pattern,omitempty" yaml:"pattern,omitempty"`
+	OneOf   []string `json:"oneof,omitempty" yaml:"oneof,omitempty"`
+	pattern *regexp.Regexp
+}
+
+// LoadSchema read the schema from JSON file, or YAML file when the extension is .yaml or .yml
+func LoadSchema(file string) (*Schema, error) {
+	b, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	ext := strings.ToLower(filepath.Ext(file))
+	if ext == ".yaml" || ext == ".yml" {
+		return ParseSchemaYAML(b)
+	}
+	return ParseSchema(b)
+}
+
+// ParseSchema parse the JSON schema and validate its rules
+func ParseSchema(b []byte) (*Schema, error) {
+	var s Schema
+	err := json.Unmarshal(b, &s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid schema: %v", err)
+	}
+	return s.validate()
+}
+
+// ParseSchemaYAML parse the YAML schema that has the same keys as JSON and validate its rules
+func ParseSchemaYAML(b []byte) (*Schema, error) {
+	var s Schema
+	err := yaml.Unmarshal(b, &s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid schema: %v", err)
+	}
+	return s.validate()
+}
+
+// UnmarshalYAML read the key null as the name of Null rule, YAML parse the plain null as the null value instead of the key
+func (c *SchemaColumn) UnmarshalYAML(n *yaml.Node) error {
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		if k := n.Content[i]; k.Tag == "!!null" && k.Value == "null" {
+			k.Tag = "!!str"
+		}
+	}
+	type column SchemaColumn //decode without UnmarshalYAML
+	return n.Decode((*column)(c))
+}
+
+func (s *Schema) validate() (*Schema, error) {
+	var err error
+	for i, c := range s.Columns {
+		if c == nil {
+			return nil, fmt.Errorf("invalid schema: column %v is empty", i)
+		}
+		if c.Name == "" && c.Index == nil {
+			return nil, fmt.Errorf("invalid schema: name or index of column %v is required", i)
+		}
+		switch c.Type {
+		case "", TypeInt, TypeFloat, TypeBool, TypeTime, TypeString:
+		default:
+			return nil, fmt.Errorf("invalid schema: type %v of column %v is not support", c.Type, c.label())
+		}
+		if c.Min != nil && *c.Min < 0 {
+			return nil, fmt.Errorf("invalid schema: min of column %v must more than zero, got: %v", c.label(), *c.Min)
+		}
+		if c.Max != nil && *c.Max < 0 {
+			return nil, fmt.Errorf("invalid schema: max of column %v must more than zero, got: %v", c.label(), *c.Max)
+		}
+		if c.Pattern != "" {
+			c.pattern, err = regexp.Compile(c.Pattern)
+			if err != nil {
+				return nil, fmt.Errorf("invalid schema: pattern of column %v is invalid, %v", c.label(), err)
+			}
+		}
+	}
+	return s, nil
+}
+
+func (c *SchemaColumn) label() string {
+	if c.Name != "" {
+		return c.Name
+	}
+	return strconv.Itoa(*c.Index)
+}
+
+// column return index of the column in the row, -1 if not found
+func (c *SchemaColumn) column(header []string) int {
+	if c.Name != "" {
+		for i, h := range header {
+			if strings.EqualFold(strings.TrimSpace(h), c.Name) {
+				return i
+			}
+		}
+		if c.Index == nil {
+			return -1
+		}
+	}
+	return *c.Index
+}
+
+func (c *SchemaColumn) isNull(val string) bool {
+	for _, n := range c.Null {
+		if val == n {
+			return true
+		}
+	}
+	return false
+}
+
+// check validate min, max, pattern and oneof of value
+func (c *SchemaColumn) check(name, value string, row int) error {
+	if c.Min != nil && len([]rune(value)) < *c.Min {
+		return fmt.Errorf("value of %v at row %v is invalid, value length must more than or equal %v, but got: %v", name, row, *c.Min, len([]rune(value)))
+	}
+	if c.Max != nil && len([]rune(value)) > *c.Max {
+		return fmt.Errorf("value of %v at row %v is invalid, value length must less than or equal %v, but got: %v", name, row, *c.Max, len([]rune(value)))
+	}
+	if c.pattern != nil && value != "" && !c.pattern.MatchString(value) {
+		return fmt.Errorf("value of %v at row %v is invalid, value must match pattern %v, but got: %v", name, row, c.Pattern, value)
+	}
+	if len(c.OneOf) > 0 && value != "" {
+		found := false
+		for _, o := range c.OneOf {
+			if o == value {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("value of %v at row %v is invalid, value must be one of %v, but got: %v", name, row, c.OneOf, value)
+		}
+	}
+	return nil
+}
+
+// checkType validate the value of dynamic record by Type
+func (c *SchemaColumn) checkType(name, value string, row int) error {
+	if value == "" {
+		return nil
+	}
+	var err error
+	switch c.Type {
+	case TypeInt:
+		_, err = strconv.Atoi(value)
+	case TypeFloat:
+		_, err = strconv.ParseFloat(value, 64)
+	case TypeBool:
+		_, err = strconv.ParseBool(value)
+	case TypeTime:
+		layout := c.Layout
+		if layout == "" {
+			layout = time.RFC3339
+		}
+		_, err = time.Parse(layout, value)
+	}
+	if err != nil {
+		return fmt.Errorf("invalid csv value at row: %v, column %v is not %v", row, name, c.Type)
+	}
+	return nil
+}
+
+// fieldIndex return index of struct field by path, -1 if not found
+func fieldIndex(fields []fieldBinding, path string) int {
+	for i, f := range fields {
+		if f.path == path {
+			return i
+		}
+	}
+	return -1
+}
+
+// validateFields check that every field in schema exist in t
+func (s *Schema) validateFields(t reflect.Type) error {
+	if t.Kind() != reflect.Struct || t == reflect.TypeOf(Record{}) {
+		return nil
+	}
+	fields := structFields(t)
+	for _, c := range s.Columns {
+		if c.Field != "" && fieldIndex(fields, c.Field) < 0 {
+			return fmt.Errorf("invalid schema: field %v of column %v is not found in %v", c.Field, c.label(), t.Name())
+		}
+	}
+	return nil
+}
+
+// applySchema map column to field by schema and keep the rules of each field,
+// header is nil when the file has no header then only index of schema column is used
+func (c *Executor[T]) applySchema(t reflect.Type, header []string) {
+	fields := structFields(t)
+	size := len(header)
+	if header == nil {
+		size = len(fields) + len(c.ops.skipper)
+	}
+
+	//start from the mapping of struct tags
+	columns := make([]int, size)
+	col := 0
+	for i := range columns {
+		columns[i] = -1
+		if c.columns != nil {
+			if i < len(c.columns) {
+				columns[i] = c.columns[i]
+			}
+			continue
+		}
+		if _, ok := c.ops.skipper[i]; ok {
+			continue
+		}
+		if col < len(fields) {
+			columns[i] = col
+		}
+		col++
+	}
+
+	rules := make(map[string]*SchemaColumn)
+	for _, sc := range c.ops.Schema.Columns {
+		i := sc.column(header)
+		if i < 0 {
+			continue
+		}
+		for i >= len(columns) {
+			columns = append(columns, -1)
+		}
+		if sc.Field != "" {
+			idx := fieldIndex(fields, sc.Field)
+			for k := range columns {
+				if columns[k] == idx {
+					//the field is moved to the column of schema
+					columns[k] = -1
+				}
+			}
+			columns[i] = idx
+		}
+		if columns[i] >= 0 {
+			rules[fields[columns[i]].path] = sc
+		}
+	}
+	c.columns = columns
+	c.rules = rules
+}
@@ -0,0 +1,253 @@
+package csvtogo
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func Test_ParseSchema(t *testing.T) {
+	tt := []struct {
+		name      string
+		schema    string
+		expectedE error
+	}{
+		{
+			name:      "should return nil when schema is valid",
+			schema:    `{"columns":[{"name":"AGE","type":"int","min":1,"pattern":"^[0-9]+$"},{"index":0,"field":"Name"}]}`,
+			expectedE: nil,
+		},
+		{
+			name:      "should return err when json is invalid",
+			schema:    `{"columns":`,
+			expectedE: errors.New("invalid schema: unexpected end of JSON input"),
+		},
+		{
+			name:      "should return err when name and index are empty",
+			schema:    `{"columns":[{"type":"int"}]}`,
+			expectedE: errors.New("invalid schema: name or index of column 0 is required"),
+		},
+		{
+			name:      "should return err when type is not support",
+			schema:    `{"columns":[{"name":"AGE","type":"decimal"}]}`,
+			expectedE: errors.New("invalid schema: type decimal of column AGE is not support"),
+		},
+		{
+			name:      "should return err when min is negative",
+			schema:    `{"columns":[{"index":2,"min":-1}]}`,
+			expectedE: errors.New("invalid schema: min of column 2 must more than zero, got: -1"),
+		},
+		{
+			name:      "should return err when pattern is invalid",
+			schema:    `{"columns":[{"name":"AGE","pattern":"[0-9"}]}`,
+			expectedE: errors.New("invalid schema: pattern of column AGE is invalid, error parsing regexp: missing closing ]: `[0-9`"),
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			_, e := ParseSchema([]byte(tc.schema))
+			if fmt.Sprintf("%v", tc.expectedE) != fmt.Sprintf("%v", e) {
+				t.Errorf("must:%v, but got: %v", tc.expectedE, e)
+			}
+		})
+	}
+}
+
+func Test_LoadSchema(t *testing.T) {
+	t.Run("should return schema when file is yaml", func(t *testing.T) {
+		err := os.WriteFile("./schema_test.yaml", []byte("columns:\n  - name: AGE\n    min: 1\n    null: [N/A, \"-\"]\n  - index: 2\n    oneof: [A, I]\n"), 0644)
+		if err != nil {
+			panic(err)
+		}
+		defer os.Remove("./schema_test.yaml")
+		s, e := LoadSchema("./schema_test.yaml")
+		if e != nil {
+			t.Fatalf("must:nil, but got: %v", e)
+		}
+		if len(s.Columns) != 2 || s.Columns[0].Name != "AGE" || *s.Columns[0].Min != 1 || fmt.Sprint(s.Columns[0].Null) != "[N/A -]" ||
+			*s.Columns[1].Index != 2 || fmt.Sprint(s.Columns[1].OneOf) != "[A I]" {
+			t.Errorf("must: schema of AGE and column 2, but got: %+v", s)
+		}
+	})
+
+	t.Run("should return err when yaml rule is invalid", func(t *testing.T) {
+		_, e := ParseSchemaYAML([]byte("columns:\n  - type: int\n"))
+		expectedE := errors.New("invalid schema: name or index of column 0 is required")
+		if fmt.Sprintf("%v", expectedE) != fmt.Sprintf("%v", e) {
+			t.Errorf("must:%v, but got: %v", expectedE, e)
+		}
+	})
+
+	t.Run("should return schema when file is json", func(t *testing.T) {
+		err := os.WriteFile("./schema_test.json", []byte(`{"columns":[{"name":"AGE","min":1}]}`), 0644)
+		if err != nil {
+			panic(err)
+		}
+		defer os.Remove("./schema_test.json")
+		s, e := LoadSchema("./schema_test.json")
+		if e != nil {
+			t.Errorf("must:nil, but got: %v", e)
+		}
+		if s == nil || len(s.Columns) != 1 || s.Columns[0].Name != "AGE" || *s.Columns[0].Min != 1 {
+			t.Errorf("must: schema of AGE, but got: %+v", s)
+		}
+	})
+}
+
+func Test_CsvToStruct_schema(t *testing.T) {
+	type Customer struct {
+		Name   string `max:"3"`
+		Age    *int
+		Status string
+	}
+	tt := []struct {
+		name      string
+		schema    string
+		ops       Options
+		content   string
+		expectedR []Customer
+		expectedE error
+	}{
+		{
+			name:    "should map column by schema and override max tag",
+			schema:  `{"columns":[{"name":"FULL NAME","field":"Name","max":10},{"name":"AGE","field":"Age","null":["N/A"]},{"name":"STATUS","field":"Status","oneof":["A","I"]}]}`,
+			ops:     Options{SkipHeader: true},
+			content: "STATUS,AGE,FULL NAME\nA,N/A,Sarah Doe\nI,12,John\n",
+			expectedR: []Customer{
+				{Name: "Sarah Doe", Status: "A"},
+				{Name: "John", Age: intPtr(12), Status: "I"},
+			},
+			expectedE: nil,
+		},
+		{
+			name:      "should return err when value is not in oneof",
+			schema:    `{"columns":[{"name":"STATUS","field":"Status","oneof":["A","I"]}]}`,
+			ops:       Options{SkipHeader: true},
+			content:   "NAME,AGE,STATUS\nAnn,12,X\n",
+			expectedR: nil,
+			expectedE: errors.New("value of Status at row 1 is invalid, value must be one of [A I], but got: X"),
+		},
+		{
+			name:      "should add pattern rule to the field that mapped by order",
+			schema:    `{"columns":[{"name":"NAME","pattern":"^[A-Z]"}]}`,
+			ops:       Options{SkipHeader: true},
+			content:   "NAME,AGE,STATUS\nann,12,A\n",
+			expectedR: nil,
+			expectedE: errors.New("value of Name at row 1 is invalid, value must match pattern ^[A-Z], but got: ann"),
+		},
+		{
+			name:    "should map column by index when no header",
+			schema:  `{"columns":[{"index":2,"field":"Name"},{"index":0,"field":"Status"}]}`,
+			ops:     Options{SkipHeader: false},
+			content: "A,12,Ann\n",
+			expectedR: []Customer{
+				{Name: "Ann", Age: intPtr(12), Status: "A"},
+			},
+			expectedE: nil,
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			err := os.WriteFile("./schema_test.csv", []byte(tc.content), 0644)
+			if err != nil {
+				panic(err)
+			}
+			defer os.Remove("./schema_test.csv")
+			s, err := ParseSchema([]byte(tc.schema))
+			if err != nil {
+				panic(err)
+			}
+			ops := tc.ops
+			ops.Schema = s
+
+			c, _ := NewClient[Customer]("./schema_test.csv", &ops)
+			r, e := c.CsvToStruct()
+			if fmt.Sprintf("%v", tc.expectedE) != fmt.Sprintf("%v", e) {
+				t.Errorf("must:%v, but got: %v", tc.expectedE, e)
+			}
+			if len(tc.expectedR) != len(r) {
+				t.Fatalf("must:%v, but got: %v", len(tc.expectedR), len(r))
+			}
+			for i := range r {
+				if !reflect.DeepEqual(tc.expectedR[i], *r[i]) {
+					t.Errorf("must:%+v, but got: %+v", tc.expectedR[i], *r[i])
+				}
+			}
+		})
+	}
+}
+
+func Test_NewClient_schemaFieldNotFound(t *testing.T) {
+	type Customer struct {
+		Name string
+	}
+	s, _ := ParseSchema([]byte(`{"columns":[{"name":"AGE","field":"Age"}]}`))
+	_ = os.WriteFile("./schema_test.csv", []byte("NAME\nAnn\n"), 0644)
+	defer os.Remove("./schema_test.csv")
+
+	_, e := NewClient[Customer]("./schema_test.csv", &Options{SkipHeader: true, Schema: s})
+	expectedE := errors.New("invalid schema: field Age of column AGE is not found in Customer")
+	if fmt.Sprintf("%v", expectedE) != fmt.Sprintf("%v", e) {
+		t.Errorf("must:%v, but got: %v", expectedE, e)
+	}
+}
+
+func Test_NewDynamicClient_schema(t *testing.T) {
+	tt := []struct {
+		name      string
+		schema    string
+		content   string
+		expectedR []map[string]string
+		expectedE error
+	}{
+		{
+			name:    "should rename column and replace null value",
+			schema:  `{"columns":[{"name":"AGE","field":"age","type":"int","null":["-"]}]}`,
+			content: "NAME,AGE\nAnn,-\n",
+			expectedR: []map[string]string{
+				{"NAME": "Ann", "age": ""},
+			},
+			expectedE: nil,
+		},
+		{
+			name:      "should return err when value is not match with type",
+			schema:    `{"columns":[{"name":"AGE","type":"int"}]}`,
+			content:   "NAME,AGE\nAnn,x\n",
+			expectedR: nil,
+			expectedE: errors.New("invalid csv value at row: 1, column AGE is not int"),
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			err := os.WriteFile("./schema_test.csv", []byte(tc.content), 0644)
+			if err != nil {
+				panic(err)
+			}
+			defer os.Remove("./schema_test.csv")
+			s, err := ParseSchema([]byte(tc.schema))
+			if err != nil {
+				panic(err)
+			}
+
+			c, _ := NewDynamicClient("./schema_test.csv", &Options{SkipHeader: true, Schema: s})
+			r, e := c.CsvToStruct()
+			if fmt.Sprintf("%v", tc.expectedE) != fmt.Sprintf("%v", e) {
+				t.Errorf("must:%v, but got: %v", tc.expectedE, e)
+			}
+			if len(tc.expectedR) != len(r) {
+				t.Fatalf("must:%v, but got: %v", len(tc.expectedR), len(r))
+			}
+			for i, val := range r {
+				if fmt.Sprintf("%v", tc.expectedR[i]) != fmt.Sprintf("%v", val.Map()) {
+					t.Errorf("must:%v, but got: %v", tc.expectedR[i], val.Map())
+				}
+			}
+		})
+	}
+}
+
+func intPtr(i int) *int {
+	return &i
+}
@@ -0,0 +1,225 @@
+package csvtogo
+
+import (
+	"bufio"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	DialectPostgres = "postgres"
+	DialectMySQL    = "mysql"
+	DialectSQLite   = "sqlite"
+)
+
+// SQLOptions is the options of WriteSQL
+type SQLOptions struct {
+	Dialect   string //DialectPostgres, DialectMySQL or DialectSQLite
+	Copy      bool   //write Postgres COPY block instead of INSERT statements
+	BatchSize int    //number of rows per INSERT statement, default is 100
+}
+
+// WriteSQL read every row of c and write INSERT statements of table to w, or COPY block when SQLOptions.Copy is set.
+// The column is the db tag of each field like Load, the value is written as SQL literal of the dialect,
+// nil pointer is NULL. The rows before the invalid row are still written when the error is returned.
+func WriteSQL[T any](w io.Writer, table string, c *Client[T], ops *SQLOptions) error {
+	if ops == nil {
+		return fmt.Errorf("dialect is required")
+	}
+	op := *ops
+	switch op.Dialect {
+	case DialectPostgres, DialectMySQL, DialectSQLite:
+	case "":
+		return fmt.Errorf("dialect is required")
+	default:
+		return fmt.Errorf("dialect %v is not support", op.Dialect)
+	}
+	if op.Copy && op.Dialect != DialectPostgres {
+		return fmt.Errorf("COPY is support by %v only", DialectPostgres)
+	}
+	if op.BatchSize <= 0 {
+		op.BatchSize = defaultBatchSize
+	}
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	if t.Kind() != reflect.Struct || t == reflect.TypeOf(Record{}) {
+		return fmt.Errorf("csvtogo is not support to write type %v, struct is required", t.String())
+	}
+	columns := dbColumns(t)
+	if len(columns) == 0 {
+		return fmt.Errorf("no column found in %v", t.Name())
+	}
+
+	names := make([]string, len(columns))
+	for i, col := range columns {
+		names[i] = quoteIdent(op.Dialect, col.name)
+	}
+	head := fmt.Sprintf("INSERT INTO %v (%v) VALUES", quoteIdent(op.Dialect, table), strings.Join(names, ", "))
+	if op.Copy {
+		head = fmt.Sprintf("COPY %v (%v) FROM stdin;\n", quoteIdent(op.Dialect, table), strings.Join(names, ", "))
+	}
+
+	bw := bufio.NewWriter(w)
+	defer bw.Flush()
+	rows := c.CsvToRows()
+	defer rows.Close()
+	n := 0
+	for rows.Next() {
+		v, err := rows.Read()
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			endSQL(bw, op, n)
+			return err
+		}
+		if v == nil {
+			continue
+		}
+
+		values := dbValues(reflect.ValueOf(v).Elem(), columns)
+		switch {
+		case op.Copy:
+			if n == 0 {
+				bw.WriteString(head)
+			}
+			for i, val := range values {
+				if i > 0 {
+					bw.WriteByte('\t')
+				}
+				bw.WriteString(copyValue(val))
+			}
+			bw.WriteByte('\n')
+		default:
+			if n%op.BatchSize == 0 {
+				bw.WriteString(head)
+			} else {
+				bw.WriteByte(',')
+			}
+			lits := make([]string, len(values))
+			for i, val := range values {
+				lits[i] = sqlLiteral(op.Dialect, val)
+			}
+			fmt.Fprintf(bw, "\n(%v)", strings.Join(lits, ", "))
+			if (n+1)%op.BatchSize == 0 {
+				bw.WriteString(";\n")
+			}
+		}
+		n++
+	}
+	endSQL(bw, op, n)
+	return bw.Flush()
+}
+
+// endSQL close the last statement or COPY block
+func endSQL(w *bufio.Writer, op SQLOptions, n int) {
+	switch {
+	case n == 0:
+	case op.Copy:
+		w.WriteString("\\.\n")
+	case n%op.BatchSize != 0:
+		w.WriteString(";\n")
+	}
+}
+
+// quoteIdent quote table or column name by the dialect, each part of schema.table is quoted
+func quoteIdent(dialect, name string) string {
+	parts := strings.Split(name, ".")
+	for i, p := range parts {
+		if dialect == DialectMySQL {
+			parts[i] = "`" + strings.ReplaceAll(p, "`", "``") + "`"
+			continue
+		}
+		parts[i] = `"` + strings.ReplaceAll(p, `"`, `""`) + `"`
+	}
+	return strings.Join(parts, ".")
+}
+
+// sqlLiteral return the value as SQL literal, the string is quoted and escaped by the dialect
+func sqlLiteral(dialect string, v interface{}) string {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return "NULL"
+		}
+		rv = rv.Elem()
+	}
+	if !rv.IsValid() {
+		return "NULL"
+	}
+
+	switch val := rv.Interface().(type) {
+	case Decimal:
+		return val.String()
+	case time.Time:
+		if dialect == DialectMySQL {
+			return quoteString(dialect, val.Format("2006-01-02 15:04:05.999999"))
+		}
+		return quoteString(dialect, val.Format("2006-01-02 15:04:05.999999-07:00"))
+	case []byte:
+		if dialect == DialectPostgres {
+			return `'\x` + hex.EncodeToString(val) + `'`
+		}
+		return "X'" + hex.EncodeToString(val) + "'"
+	}
+
+	switch rv.Kind() {
+	case reflect.Bool:
+		if dialect == DialectPostgres {
+			return strings.ToUpper(strconv.FormatBool(rv.Bool()))
+		}
+		if rv.Bool() {
+			return "1"
+		}
+		return "0"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(rv.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(rv.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		f := rv.Float()
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			//no literal in MySQL and SQLite
+			if dialect == DialectPostgres {
+				return quoteString(dialect, strconv.FormatFloat(f, 'f', -1, 64))
+			}
+			return "NULL"
+		}
+		return strconv.FormatFloat(f, 'f', -1, rv.Type().Bits())
+	case reflect.Slice, reflect.Map:
+		//joined by the default split and kv tag, the field value is already joined by dbValues
+		return quoteString(dialect, joinCell(rv, reflect.StructField{}))
+	}
+	return quoteString(dialect, fmt.Sprintf("%v", rv.Interface()))
+}
+
+// quoteString quote s by single quote, MySQL also escape backslash by default
+func quoteString(dialect, s string) string {
+	s = strings.ReplaceAll(s, "'", "''")
+	if dialect == DialectMySQL {
+		s = strings.ReplaceAll(s, `\`, `\\`)
+	}
+	return "'" + s + "'"
+}
+
+// copyValue return the value in text format of Postgres COPY, NULL is \N
+func copyValue(v interface{}) string {
+	lit := sqlLiteral(DialectPostgres, v)
+	switch {
+	case lit == "NULL":
+		return `\N`
+	case lit == "TRUE":
+		return "t"
+	case lit == "FALSE":
+		return "f"
+	case strings.HasPrefix(lit, "'"):
+		//unquote then escape by COPY rules
+		lit = strings.ReplaceAll(lit[1:len(lit)-1], "''", "'")
+	}
+	return strings.NewReplacer(`\`, `\\`, "\t", `\t`, "\n", `\n`, "\r", `\r`).Replace(lit)
+}
@@ -0,0 +1,146 @@
+package csvtogo
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+)
+
+func Test_WriteSQL(t *testing.T) {
+	type Customer struct {
+		Name     string     `csv:"name" db:"full_name"`
+		Age      *int       `csv:"age"`
+		Active   bool       `csv:"active"`
+		CreateAt *time.Time `csv:"create_at" layout:"2006-01-02"`
+		Note     string     `csv:"note" db:"-"`
+	}
+	content := "name,age,active,create_at,note\nO'Neil \\ Tab\t,12,true,2022-01-31,x\nJohn,,false,,x\n"
+	tt := []struct {
+		name      string
+		ops       *SQLOptions
+		content   string
+		expectedR string
+		expectedE error
+	}{
+		{
+			name:    "should write postgres insert statement",
+			ops:     &SQLOptions{Dialect: DialectPostgres},
+			content: content,
+			expectedR: "INSERT INTO \"public\".\"customer\" (\"full_name\", \"age\", \"active\", \"create_at\") VALUES\n" +
+				"('O''Neil \\ Tab\t', 12, TRUE, '2022-01-31 00:00:00+00:00'),\n" +
+				"('John', NULL, FALSE, NULL);\n",
+		},
+		{
+			name:    "should write mysql insert statement by batch",
+			ops:     &SQLOptions{Dialect: DialectMySQL, BatchSize: 1},
+			content: content,
+			expectedR: "INSERT INTO `public`.`customer` (`full_name`, `age`, `active`, `create_at`) VALUES\n" +
+				"('O''Neil \\\\ Tab\t', 12, 1, '2022-01-31 00:00:00');\n" +
+				"INSERT INTO `public`.`customer` (`full_name`, `age`, `active`, `create_at`) VALUES\n" +
+				"('John', NULL, 0, NULL);\n",
+		},
+		{
+			name:    "should write postgres copy block",
+			ops:     &SQLOptions{Dialect: DialectPostgres, Copy: true},
+			content: content,
+			expectedR: "COPY \"public\".\"customer\" (\"full_name\", \"age\", \"active\", \"create_at\") FROM stdin;\n" +
+				"O'Neil \\\\ Tab\\t\t12\tt\t2022-01-31 00:00:00+00:00\n" +
+				"John\t\\N\tf\t\\N\n" +
+				"\\.\n",
+		},
+		{
+			name:      "should write nothing when no row",
+			ops:       &SQLOptions{Dialect: DialectSQLite},
+			content:   "name,age,active,create_at,note\n",
+			expectedR: "",
+		},
+		{
+			name:      "should write rows before invalid row and return error",
+			ops:       &SQLOptions{Dialect: DialectSQLite},
+			content:   "name,age,active,create_at,note\nJohn,1,true,,x\nJane,x,true,,x\n",
+			expectedR: "INSERT INTO \"public\".\"customer\" (\"full_name\", \"age\", \"active\", \"create_at\") VALUES\n('John', 1, 1, NULL);\n",
+			expectedE: errors.New("invalid csv value at row: 2, the struct accept type int"),
+		},
+		{
+			name:      "should return error when copy is not postgres",
+			ops:       &SQLOptions{Dialect: DialectMySQL, Copy: true},
+			content:   content,
+			expectedE: errors.New("COPY is support by postgres only"),
+		},
+		{
+			name:      "should return error when dialect is not support",
+			ops:       &SQLOptions{Dialect: "oracle"},
+			content:   content,
+			expectedE: errors.New("dialect oracle is not support"),
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			err := os.WriteFile("./sqlgen_test.csv", []byte(tc.content), 0644)
+			if err != nil {
+				panic(err)
+			}
+			defer os.Remove("./sqlgen_test.csv")
+
+			c, _ := NewClient[Customer]("./sqlgen_test.csv")
+			var w bytes.Buffer
+			e := WriteSQL(&w, "public.customer", c, tc.ops)
+			if fmt.Sprintf("%v", tc.expectedE) != fmt.Sprintf("%v", e) {
+				t.Errorf("must:%v, but got: %v", tc.expectedE, e)
+			}
+			if tc.expectedR != w.String() {
+				t.Errorf("must:%q, but got: %q", tc.expectedR, w.String())
+			}
+		})
+	}
+}
+
+func Test_sqlLiteral(t *testing.T) {
+	tt := []struct {
+		name      string
+		dialect   string
+		value     interface{}
+		expectedR string
+	}{
+		{name: "should write bytea of postgres", dialect: DialectPostgres, value: []byte{0x01, 0xAB}, expectedR: `'\x01ab'`},
+		{name: "should write blob of sqlite", dialect: DialectSQLite, value: []byte{0x01, 0xAB}, expectedR: `X'01ab'`},
+		{name: "should write float without exponent", dialect: DialectSQLite, value: 1e-7, expectedR: `0.0000001`},
+		{name: "should write nil as NULL", dialect: DialectSQLite, value: nil, expectedR: `NULL`},
+		{name: "should join slice by default split", dialect: DialectSQLite, value: []string{"a", "it's"}, expectedR: `'a,it''s'`},
+		{name: "should join map by default kv order by key", dialect: DialectSQLite, value: map[string]int{"b": 2, "a": 1}, expectedR: `'a=1,b=2'`},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			r := sqlLiteral(tc.dialect, tc.value)
+			if tc.expectedR != r {
+				t.Errorf("must:%v, but got: %v", tc.expectedR, r)
+			}
+		})
+	}
+}
+
+func Test_WriteSQL_slice(t *testing.T) {
+	type Product struct {
+		Name string   `csv:"name"`
+		Tags []string `csv:"tags" split:";"`
+	}
+	err := os.WriteFile("./sqlgen_slice_test.csv", []byte("name,tags\nShirt,a;b\n"), 0644)
+	if err != nil {
+		panic(err)
+	}
+	defer os.Remove("./sqlgen_slice_test.csv")
+
+	c, _ := NewClient[Product]("./sqlgen_slice_test.csv")
+	var w bytes.Buffer
+	e := WriteSQL(&w, "product", c, &SQLOptions{Dialect: DialectSQLite})
+	if e != nil {
+		t.Errorf("must:nil, but got: %v", e)
+	}
+	expected := "INSERT INTO \"product\" (\"name\", \"tags\") VALUES\n('Shirt', 'a;b');\n"
+	if expected != w.String() {
+		t.Errorf("must:%q, but got: %q", expected, w.String())
+	}
+}
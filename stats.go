@@ -0,0 +1,58 @@
+package csvtogo
+
+import "sync/atomic"
+
+// Stats is the number of rows that read by client, header row is not counted
+type Stats struct {
+	Rows     int //rows read from the source
+	Filtered int //rows rejected by RawFilter or WithFilter
+	Returned int //rows returned by Read
+}
+
+// readStats is the counters that shared by every reader of client, the rows are counted concurrently
+type readStats struct {
+	rows     int64
+	filtered int64
+	returned int64
+}
+
+func (s *readStats) row() {
+	if s != nil {
+		atomic.AddInt64(&s.rows, 1)
+	}
+}
+
+func (s *readStats) filter() {
+	if s != nil {
+		atomic.AddInt64(&s.filtered, 1)
+	}
+}
+
+func (s *readStats) returnRow() {
+	if s != nil {
+		atomic.AddInt64(&s.returned, 1)
+	}
+}
+
+// keepRaw count the row and return false if it's rejected by RawFilter, the row is skipped before conversion
+func (o Options) keepRaw(data []string) bool {
+	o.stats.row()
+	if o.RawFilter != nil && !o.RawFilter(data) {
+		o.stats.filter()
+		return false
+	}
+	return true
+}
+
+// Stats return the number of rows that read, filtered and returned so far
+func (c *Executor[T]) Stats() Stats {
+	s := c.ops.stats
+	if s == nil {
+		return Stats{}
+	}
+	return Stats{
+		Rows:     int(atomic.LoadInt64(&s.rows)),
+		Filtered: int(atomic.LoadInt64(&s.filtered)),
+		Returned: int(atomic.LoadInt64(&s.returned)),
+	}
+}
@@ -0,0 +1,95 @@
+package csvtogo
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+)
+
+func Test_Filter(t *testing.T) {
+	type Order struct {
+		ID     int    `csv:"id"`
+		Status string `csv:"status"`
+		Email  string `csv:"email" max:"10"`
+	}
+	tt := []struct {
+		name          string
+		ops           *Options
+		filter        func(*Order) bool
+		content       string
+		expectedR     string
+		expectedStats Stats
+		expectedE     error
+	}{
+		{
+			name: "should skip rows by raw filter and filter",
+			ops: &Options{
+				SkipHeader: true,
+				RawFilter:  func(d []string) bool { return d[1] != "cancelled" },
+			},
+			filter:        func(o *Order) bool { return !strings.HasSuffix(o.Email, "@test") },
+			content:       "id,status,email\n1,paid,a@b.c\n2,cancelled,x\n3,paid,too-long-address@test\n4,paid,d@e.f\n",
+			expectedR:     "[1 4]",
+			expectedStats: Stats{Rows: 4, Filtered: 2, Returned: 2},
+		},
+		{
+			name:          "should count every row when no filter",
+			ops:           &Options{SkipHeader: true},
+			content:       "id,status,email\n1,paid,a@b.c\n",
+			expectedR:     "[1]",
+			expectedStats: Stats{Rows: 1, Returned: 1},
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			err := os.WriteFile("./filter_test.csv", []byte(tc.content), 0644)
+			if err != nil {
+				panic(err)
+			}
+			defer os.Remove("./filter_test.csv")
+
+			c, e := NewClient[Order]("./filter_test.csv", tc.ops)
+			if fmt.Sprintf("%v", tc.expectedE) != fmt.Sprintf("%v", e) {
+				t.Fatalf("must:%v, but got: %v", tc.expectedE, e)
+			}
+			if tc.filter != nil {
+				c.WithFilter(tc.filter)
+			}
+			r, e := c.CsvToStruct()
+			if e != nil {
+				t.Fatalf("must:nil, but got: %v", e)
+			}
+			var ids []int
+			for _, o := range r {
+				ids = append(ids, o.ID)
+			}
+			if tc.expectedR != fmt.Sprintf("%v", ids) {
+				t.Errorf("must:%v, but got: %v", tc.expectedR, ids)
+			}
+			if tc.expectedStats != c.Stats() {
+				t.Errorf("must:%v, but got: %v", tc.expectedStats, c.Stats())
+			}
+		})
+	}
+}
+
+func Test_Filter_record(t *testing.T) {
+	err := os.WriteFile("./filter_test.csv", []byte("id,status\n1,paid\n2,cancelled\n"), 0644)
+	if err != nil {
+		panic(err)
+	}
+	defer os.Remove("./filter_test.csv")
+
+	c, _ := NewDynamicClient("./filter_test.csv", &Options{SkipHeader: true})
+	r, e := c.WithFilter(func(r *Record) bool { return r.GetString("status") != "cancelled" }).CsvToStruct()
+	if e != nil {
+		t.Fatalf("must:nil, but got: %v", e)
+	}
+	if len(r) != 1 || r[0].GetString("id") != "1" {
+		t.Errorf("must:%v, but got: %v", 1, len(r))
+	}
+	if (Stats{Rows: 2, Filtered: 1, Returned: 1}) != c.Stats() {
+		t.Errorf("must:%v, but got: %v", Stats{Rows: 2, Filtered: 1, Returned: 1}, c.Stats())
+	}
+}
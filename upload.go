@@ -0,0 +1,213 @@
+package csvtogo
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+)
+
+const (
+	defaultMaxBytes        = 32 << 20
+	defaultUploadBatchSize = 1000
+	defaultUploadField     = "file"
+)
+
+// errTooLarge is returned when the body is more than UploadOptions.MaxBytes
+var errTooLarge = errors.New("request body too large")
+
+// UploadOptions is the options of UploadHandler
+type UploadOptions struct {
+	Options   *Options //reader options, Format is detected by file name or Content-Type if empty
+	MaxBytes  int64    //maximum size of request body, default is 32MB
+	BatchSize int      //number of rows per handle call, default is 1000
+	Field     string   //form field of the file in multipart body, default is file
+}
+
+// UploadReport is the JSON response of UploadHandler
+type UploadReport struct {
+	Rows       int         `json:"rows"`
+	Error      string      `json:"error,omitempty"`
+	Violations []Violation `json:"violations,omitempty"`
+}
+
+// UploadHandler return the handler that read the uploaded csv from multipart form or raw body,
+// the rows are streamed through the reader and passed to handle by batch, the body is not buffered.
+// The handle is not called with the rows after the invalid row, but the previous batches are already handled.
+// The response is UploadReport with 200, 400 / 422 with row and column of the invalid value, 413 if body is too large
+// or 500 if handle return error, the error of handle is not written to the response.
+func UploadHandler[T any](ops *UploadOptions, handle func(ctx context.Context, rows []T) error) http.Handler {
+	op := UploadOptions{}
+	if ops != nil {
+		op = *ops
+	}
+	if op.MaxBytes <= 0 {
+		op.MaxBytes = defaultMaxBytes
+	}
+	if op.BatchSize <= 0 {
+		op.BatchSize = defaultUploadBatchSize
+	}
+	if op.Field == "" {
+		op.Field = defaultUploadField
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost && r.Method != http.MethodPut {
+			w.Header().Set("Allow", "POST, PUT")
+			writeReport(w, http.StatusMethodNotAllowed, &UploadReport{Error: "method is not allowed"})
+			return
+		}
+		body, name, err := uploadBody(r, &maxReader{r: r.Body, n: op.MaxBytes}, op.Field)
+		if err != nil {
+			writeReport(w, uploadStatus(err), &UploadReport{Error: err.Error()})
+			return
+		}
+
+		ro := _defaultOps
+		if op.Options != nil {
+			ro = *op.Options
+		}
+		if ro.Format == "" && name == "" {
+			ro.Format = contentFormat(r.Header.Get("Content-Type"))
+		}
+		c, err := NewClientReader[T](body, &ro)
+		if err != nil {
+			writeReport(w, http.StatusInternalServerError, &UploadReport{Error: err.Error()})
+			return
+		}
+		c.srcName = name
+
+		report := &UploadReport{}
+		rows := c.CsvToRows()
+		defer rows.Close()
+		batch := make([]T, 0, op.BatchSize)
+		flush := func() bool {
+			if len(batch) == 0 {
+				return true
+			}
+			if err := handle(r.Context(), batch); err != nil {
+				report.Error = "failed to handle the rows"
+				writeReport(w, http.StatusInternalServerError, report)
+				return false
+			}
+			report.Rows += len(batch)
+			batch = make([]T, 0, op.BatchSize)
+			return true
+		}
+		for rows.Next() {
+			v, err := rows.Read()
+			if err != nil {
+				if err == io.EOF {
+					break
+				}
+				report.Error = err.Error()
+				report.Violations = violationsOf(err)
+				writeReport(w, uploadStatus(err), report)
+				return
+			}
+			if v == nil {
+				continue
+			}
+			batch = append(batch, *v)
+			if len(batch) >= op.BatchSize && !flush() {
+				return
+			}
+		}
+		if flush() {
+			writeReport(w, http.StatusOK, report)
+		}
+	})
+}
+
+// uploadBody return the file part of multipart body or the raw body, name is the file name of the part
+func uploadBody(r *http.Request, body io.Reader, field string) (io.Reader, string, error) {
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType != "multipart/form-data" {
+		return body, "", nil
+	}
+	r.Body = io.NopCloser(body)
+	mr, err := r.MultipartReader()
+	if err != nil {
+		return nil, "", err
+	}
+	for {
+		part, err := mr.NextPart()
+		if err == io.EOF {
+			return nil, "", fmt.Errorf("form field %v is required", field)
+		}
+		if err != nil {
+			return nil, "", err
+		}
+		if part.FormName() == field {
+			return part, part.FileName(), nil
+		}
+	}
+}
+
+// contentFormat return the format of raw body by Content-Type, empty if it's csv or unknown
+func contentFormat(contentType string) string {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	switch mediaType {
+	case "application/x-ndjson", "application/jsonl", "application/x-jsonlines":
+		return FormatNDJSON
+	case "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet":
+		return FormatXLSX
+	}
+	return ""
+}
+
+// violationsOf return the row and column of invalid value or csv syntax error
+func violationsOf(err error) []Violation {
+	var re *RowError
+	if errors.As(err, &re) {
+		return []Violation{{Row: re.Row, Column: re.Column, Value: re.Value, Message: re.Error()}}
+	}
+	var pe *csv.ParseError
+	if errors.As(err, &pe) {
+		return []Violation{{Row: pe.Line, Column: strconv.Itoa(pe.Column), Message: pe.Err.Error()}}
+	}
+	return nil
+}
+
+func uploadStatus(err error) int {
+	var re *RowError
+	switch {
+	case errors.Is(err, errTooLarge):
+		return http.StatusRequestEntityTooLarge
+	case errors.As(err, &re):
+		return http.StatusUnprocessableEntity
+	}
+	return http.StatusBadRequest
+}
+
+func writeReport(w http.ResponseWriter, status int, report *UploadReport) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(report)
+}
+
+// maxReader return errTooLarge when read more than n bytes
+type maxReader struct {
+	r io.Reader
+	n int64
+}
+
+func (m *maxReader) Read(p []byte) (int, error) {
+	if m.n < 0 {
+		return 0, errTooLarge
+	}
+	if int64(len(p)) > m.n+1 {
+		p = p[:m.n+1]
+	}
+	n, err := m.r.Read(p)
+	m.n -= int64(n)
+	if m.n < 0 {
+		return n, errTooLarge
+	}
+	return n, err
+}
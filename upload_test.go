@@ -0,0 +1,134 @@
+package csvtogo
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func Test_UploadHandler(t *testing.T) {
+	type Customer struct {
+		Name string `csv:"name" min:"1"`
+		Age  int    `csv:"age"`
+		File string `source:"file"`
+	}
+	multipartBody := func(field, file, content string) (string, *bytes.Buffer) {
+		var b bytes.Buffer
+		mw := multipart.NewWriter(&b)
+		_ = mw.WriteField("note", "x")
+		fw, _ := mw.CreateFormFile(field, file)
+		_, _ = fw.Write([]byte(content))
+		_ = mw.Close()
+		return mw.FormDataContentType(), &b
+	}
+	tt := []struct {
+		name           string
+		ops            *UploadOptions
+		method         string
+		contentType    string
+		body           func() (string, *bytes.Buffer)
+		handleErr      error
+		expectedStatus int
+		expectedR      string
+		expectedRows   string
+	}{
+		{
+			name: "should handle rows of raw body by batch",
+			ops:  &UploadOptions{BatchSize: 2},
+			body: func() (string, *bytes.Buffer) {
+				return "text/csv", bytes.NewBufferString("name,age\nSarah,12\nJohn,21\nJane,1\n")
+			},
+			expectedStatus: http.StatusOK,
+			expectedR:      `{"rows":3}`,
+			expectedRows:   "[{Sarah 12 } {John 21 }] [{Jane 1 }]",
+		},
+		{
+			name: "should read file of multipart form",
+			body: func() (string, *bytes.Buffer) {
+				return multipartBody("file", "customer.jsonl", `{"name":"Sarah","age":12}`)
+			},
+			expectedStatus: http.StatusOK,
+			expectedR:      `{"rows":1}`,
+			expectedRows:   "[{Sarah 12 customer.jsonl}]",
+		},
+		{
+			name: "should return row and column of invalid value",
+			body: func() (string, *bytes.Buffer) {
+				return "text/csv", bytes.NewBufferString("name,age\nSarah,12\nJohn,x\n")
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedR:      `{"rows":0,"error":"invalid csv value at row: 2, the struct accept type int","violations":[{"row":2,"column":"age","value":"x","message":"invalid csv value at row: 2, the struct accept type int"}]}`,
+		},
+		{
+			name:           "should return line and column of csv syntax error",
+			body:           func() (string, *bytes.Buffer) { return "text/csv", bytes.NewBufferString("name,age\n\"Sarah,12\n") },
+			expectedStatus: http.StatusBadRequest,
+			expectedR:      `{"rows":0,"error":"parse error on line 2, column 11: extraneous or missing \" in quoted-field","violations":[{"row":2,"column":"11","value":"","message":"extraneous or missing \" in quoted-field"}]}`,
+		},
+		{
+			name:           "should return error when body is too large",
+			ops:            &UploadOptions{MaxBytes: 10},
+			body:           func() (string, *bytes.Buffer) { return "text/csv", bytes.NewBufferString("name,age\nSarah,12\n") },
+			expectedStatus: http.StatusRequestEntityTooLarge,
+			expectedR:      `{"rows":0,"error":"request body too large"}`,
+		},
+		{
+			name:           "should return error when file field is not found",
+			body:           func() (string, *bytes.Buffer) { return multipartBody("other", "customer.csv", "name,age\n") },
+			expectedStatus: http.StatusBadRequest,
+			expectedR:      `{"rows":0,"error":"form field file is required"}`,
+		},
+		{
+			name:           "should not write error of handle",
+			body:           func() (string, *bytes.Buffer) { return "text/csv", bytes.NewBufferString("name,age\nSarah,12\n") },
+			handleErr:      errors.New("connection refused"),
+			expectedStatus: http.StatusInternalServerError,
+			expectedR:      `{"rows":0,"error":"failed to handle the rows"}`,
+		},
+		{
+			name:           "should allow post and put only",
+			method:         http.MethodGet,
+			body:           func() (string, *bytes.Buffer) { return "text/csv", &bytes.Buffer{} },
+			expectedStatus: http.StatusMethodNotAllowed,
+			expectedR:      `{"rows":0,"error":"method is not allowed"}`,
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var handled []string
+			h := UploadHandler[Customer](tc.ops, func(ctx context.Context, rows []Customer) error {
+				var s []string
+				for _, r := range rows {
+					s = append(s, fmt.Sprintf("{%v %v %v}", r.Name, r.Age, r.File))
+				}
+				handled = append(handled, "["+strings.Join(s, " ")+"]")
+				return tc.handleErr
+			})
+			method := tc.method
+			if method == "" {
+				method = http.MethodPost
+			}
+			contentType, body := tc.body()
+			req := httptest.NewRequest(method, "/upload", body)
+			req.Header.Set("Content-Type", contentType)
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, req)
+
+			if tc.expectedStatus != w.Code {
+				t.Errorf("must:%v, but got: %v", tc.expectedStatus, w.Code)
+			}
+			if tc.expectedR != strings.TrimSpace(w.Body.String()) {
+				t.Errorf("must:%v, but got: %v", tc.expectedR, w.Body.String())
+			}
+			if tc.expectedRows != "" && tc.expectedRows != strings.Join(handled, " ") {
+				t.Errorf("must:%v, but got: %v", tc.expectedRows, strings.Join(handled, " "))
+			}
+		})
+	}
+}
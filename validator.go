@@ -2,7 +2,9 @@ package csvtogo
 
 import (
 	"fmt"
+	"io"
 	"reflect"
+	"sort"
 	"strconv"
 )
 
@@ -12,25 +14,58 @@ const (
 )
 
 func validateStruct[T any](f T, row int) error {
-	v := reflect.ValueOf(&f).Elem()
-	for i := 0; i < v.NumField(); i++ {
+	return validateValue(reflect.ValueOf(&f).Elem(), row, nil)
+}
+
+// validateValue validate every field of v by struct tags and schema rules of each field path
+func validateValue(v reflect.Value, row int, rules map[string]*SchemaColumn) error {
+	for _, b := range structFields(v.Type()) {
+		fv, ok := lookupField(v, b.index)
+		if !ok {
+			//nested struct is nil, nothing to validate
+			continue
+		}
+
+		rule := rules[b.path]
 		//check minimum value length
-		err := checkMin[T](f, i, row, v)
-		if err != nil {
-			return err
+		if rule == nil || rule.Min == nil {
+			err := checkMinField(b.field, b.path, fv, row)
+			if err != nil {
+				return rowError(row, b.name, fieldString(fv), err)
+			}
 		}
 
 		//check maximum value length
-		err = checkMax[T](f, i, row, v)
+		if rule == nil || rule.Max == nil {
+			err := checkMaxField(b.field, b.path, fv, row)
+			if err != nil {
+				return rowError(row, b.name, fieldString(fv), err)
+			}
+		}
+
+		//check maximum digits after the point of decimal
+		err := checkScaleField(b.field, b.path, fv, row)
 		if err != nil {
-			return err
+			return rowError(row, b.name, fieldString(fv), err)
+		}
+
+		//schema rules
+		if rule != nil {
+			err := rule.check(b.path, fieldString(fv), row)
+			if err != nil {
+				return rowError(row, b.name, fieldString(fv), err)
+			}
 		}
 	}
 	return nil
 }
 
 func checkMin[T any](field T, sequence, row int, v reflect.Value) error {
-	minimum, err := isTagFound[T](field, sequence, tagMin, v)
+	return checkMinField(v.Type().Field(sequence), v.Type().Field(sequence).Name, v.Field(sequence), row)
+}
+
+func checkMinField(sf reflect.StructField, name string, fv reflect.Value, row int) error {
+	minimum, err := tagValue(sf, name, tagMin)
 	if err != nil {
 		return err
 	}
@@ -39,10 +74,10 @@ func checkMin[T any](field T, sequence, row int, v reflect.Value) error {
 		return nil
 	}
 
-	value := fmt.Sprintf("%v", v.Field(sequence).Interface())
+	value := fieldString(fv)
 	if len([]rune(value)) < minimum {
 		return fmt.Errorf("value of %v at row %v is invalid, value length must more than or equal %v, but got: %v",
-			v.Type().Field(sequence).Name,
+			name,
 			row,
 			minimum,
 			len([]rune(value)),
@@ -52,7 +87,11 @@ func checkMin[T any](field T, sequence, row int, v reflect.Value) error {
 }
 
 func checkMax[T any](field T, sequence, row int, v reflect.Value) error {
-	maximum, err := isTagFound[T](field, sequence, tagMax, v)
+	return checkMaxField(v.Type().Field(sequence), v.Type().Field(sequence).Name, v.Field(sequence), row)
+}
+
+func checkMaxField(sf reflect.StructField, name string, fv reflect.Value, row int) error {
+	maximum, err := tagValue(sf, name, tagMax)
 	if err != nil {
 		return err
 	}
@@ -61,10 +100,10 @@ func checkMax[T any](field T, sequence, row int, v reflect.Value) error {
 		return nil
 	}
 
-	value := fmt.Sprintf("%v", v.Field(sequence).Interface())
+	value := fieldString(fv)
 	if len([]rune(value)) > maximum {
 		return fmt.Errorf("value of %v at row %v is invalid, value length must less than or equal %v, but got: %v",
-			v.Type().Field(sequence).Name,
+			name,
 			row,
 			maximum,
 			len([]rune(value)),
@@ -74,16 +113,139 @@ func checkMax[T any](field T, sequence, row int, v reflect.Value) error {
 }
 
 func isTagFound[T any](field T, sequence int, tag string, v reflect.Value) (int, error) {
-	tmp := reflect.TypeOf(&field).Elem().Field(sequence).Tag.Get(tag)
+	return tagValue(reflect.TypeOf(&field).Elem().Field(sequence), v.Type().Field(sequence).Name, tag)
+}
+
+func tagValue(sf reflect.StructField, name string, tag string) (int, error) {
+	tmp := sf.Tag.Get(tag)
 	if len(tmp) > 0 {
 		val, err := strconv.Atoi(tmp)
 		if err != nil {
-			return -1, fmt.Errorf("tag %v of field %v must be integer, got: %v", tag, v.Type().Field(sequence).Name, tmp)
+			return -1, fmt.Errorf("tag %v of field %v must be integer, got: %v", tag, name, tmp)
 		}
 		if val < 0 {
-			return -1, fmt.Errorf("tag %v of field %v must more than zero, got: %v", tag, v.Type().Field(sequence).Name, tmp)
+			return -1, fmt.Errorf("tag %v of field %v must more than zero, got: %v", tag, name, tmp)
 		}
 		return val, nil
 	}
 	return -1, nil
 }
+
+// fieldString return the value of field as string, nil pointer is empty
+func fieldString(fv reflect.Value) string {
+	if fv.Kind() == reflect.Ptr {
+		if fv.IsNil() {
+			return ""
+		}
+		fv = fv.Elem()
+	}
+	return fmt.Sprintf("%v", fv.Interface())
+}
+
+// RowError is the error of the invalid row, Column is the header name of the field if known.
+// The message is the same as the wrapped error, use errors.As to get the details.
+type RowError struct {
+	Row    int
+	Column string
+	Value  string
+	Err    error
+}
+
+func (e *RowError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *RowError) Unwrap() error {
+	return e.Err
+}
+
+func rowError(row int, column, value string, err error) error {
+	return &RowError{Row: row, Column: column, Value: value, Err: err}
+}
+
+// Violation is the invalid value that found by Validate
+type Violation struct {
+	Row     int    `json:"row"`
+	Column  string `json:"column"`
+	Value   string `json:"value"`
+	Message string `json:"message"`
+}
+
+// Validate read every row of file and return every violation of schema, order by row.
+// The row that can't be parsed such as wrong number of fields is also a violation, the next rows are still validated.
+// The error is returned only when the file can't be read.
+func Validate(file string, schema *Schema, ops ...*Options) ([]Violation, error) {
+	if schema == nil {
+		return nil, fmt.Errorf("schema is required")
+	}
+	op := _defaultOps
+	if len(ops) > 0 && ops[0] != nil {
+		op = *ops[0]
+	}
+	//called by the reader one row at a time in the order of the file, before the last row is read, see finish
+	var invalid []Violation
+	op.invalidRow = func(e *RowError) {
+		invalid = append(invalid, Violation{Row: e.Row, Column: e.Column, Value: e.Value, Message: e.Error()})
+	}
+	c, err := NewDynamicClient(file, &op)
+	if err != nil {
+		return nil, err
+	}
+	rows := c.CsvToRows()
+	defer rows.Close()
+
+	var violations []Violation
+	for rows.Next() {
+		r, err := rows.Read()
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, err
+		}
+		if r == nil {
+			continue
+		}
+		violations = append(violations, schema.violations(*r)...)
+	}
+	violations = append(violations, invalid...)
+	sort.SliceStable(violations, func(i, j int) bool {
+		return violations[i].Row < violations[j].Row
+	})
+	return violations, nil
+}
+
+// violations validate every column of record
+func (s *Schema) violations(r Record) []Violation {
+	var violations []Violation
+	for _, sc := range s.Columns {
+		i := sc.column(r.header)
+		if i < 0 || i >= len(r.values) {
+			if sc.Min != nil && *sc.Min > 0 {
+				violations = append(violations, Violation{
+					Row:     r.row,
+					Column:  sc.label(),
+					Message: fmt.Sprintf("column %v is not found at row %v", sc.label(), r.row),
+				})
+			}
+			continue
+		}
+		value := r.values[i]
+		if sc.isNull(value) {
+			value = ""
+		}
+		err := sc.checkType(sc.label(), value, r.row)
+		if err == nil {
+			err = sc.check(sc.label(), value, r.row)
+		}
+		if err != nil {
+			violations = append(violations, Violation{
+				Row:     r.row,
+				Column:  sc.label(),
+				Value:   r.values[i],
+				Message: err.Error(),
+			})
+		}
+	}
+	return violations
+}
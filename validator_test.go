@@ -3,6 +3,7 @@ package csvtogo
 import (
 	"errors"
 	"fmt"
+	"os"
 	"reflect"
 	"strings"
 	"testing"
@@ -245,3 +246,97 @@ func Test_isTagFound(t *testing.T) {
 		})
 	}
 }
+
+func Test_Validate(t *testing.T) {
+	schema, err := ParseSchema([]byte(`{"columns":[
+		{"name":"NAME","min":1},
+		{"name":"AGE","type":"int","null":["N/A"]},
+		{"name":"STATUS","oneof":["A","I"]},
+		{"name":"EMAIL","min":1}
+	]}`))
+	if err != nil {
+		panic(err)
+	}
+	tt := []struct {
+		name      string
+		schema    *Schema
+		ops       *Options
+		content   string
+		expectedR []Violation
+		expectedE error
+	}{
+		{
+			name:      "should return empty when every row is valid",
+			schema:    schema,
+			content:   "NAME,AGE,STATUS,EMAIL\nAnn,N/A,A,a@b.c\n",
+			expectedR: nil,
+			expectedE: nil,
+		},
+		{
+			name:    "should return every violation order by row",
+			schema:  schema,
+			content: "NAME,AGE,STATUS,EMAIL\nAnn,x,A,a@b.c\n,12,X,a@b.c\nJohn,1,I\n",
+			expectedR: []Violation{
+				{Row: 1, Column: "AGE", Value: "x", Message: "invalid csv value at row: 1, column AGE is not int"},
+				{Row: 2, Column: "NAME", Value: "", Message: "value of NAME at row 2 is invalid, value length must more than or equal 1, but got: 0"},
+				{Row: 2, Column: "STATUS", Value: "X", Message: "value of STATUS at row 2 is invalid, value must be one of [A I], but got: X"},
+				{Row: 3, Column: "EMAIL", Value: "", Message: "column EMAIL is not found at row 3"},
+			},
+			expectedE: nil,
+		},
+		{
+			name:    "should report wrong number of fields and continue",
+			schema:  schema,
+			ops:     &Options{SkipHeader: true},
+			content: "NAME,AGE,STATUS,EMAIL\nAnn,1,A\nBob,x,A,b@c.d\nJo\"e,1,A,j@c.d\nEve,2,I,e@c.d,extra\n",
+			expectedR: []Violation{
+				{Row: 1, Column: "", Value: "Ann,1,A", Message: "number of column is not match at row: 1, expected: 4, got: 3"},
+				{Row: 2, Column: "AGE", Value: "x", Message: "invalid csv value at row: 2, column AGE is not int"},
+				{Row: 3, Column: "", Value: "", Message: "invalid csv at row: 3, bare \" in non-quoted-field"},
+				{Row: 4, Column: "", Value: "Eve,2,I,e@c.d,extra", Message: "number of column is not match at row: 4, expected: 4, got: 5"},
+			},
+			expectedE: nil,
+		},
+		{
+			name:    "should report schema of options with column in the order of the file",
+			schema:  schema,
+			ops:     &Options{SkipHeader: true, Schema: schema},
+			content: "NAME,AGE,STATUS,EMAIL\nAnn,1,A\n,1,A,b@c.d\nJo,1,A\nBob,1,A,\n",
+			expectedR: []Violation{
+				{Row: 1, Column: "", Value: "Ann,1,A", Message: "number of column is not match at row: 1, expected: 4, got: 3"},
+				{Row: 2, Column: "NAME", Value: "", Message: "value of NAME at row 2 is invalid, value length must more than or equal 1, but got: 0"},
+				{Row: 3, Column: "", Value: "Jo,1,A", Message: "number of column is not match at row: 3, expected: 4, got: 3"},
+				{Row: 4, Column: "EMAIL", Value: "", Message: "value of EMAIL at row 4 is invalid, value length must more than or equal 1, but got: 0"},
+			},
+			expectedE: nil,
+		},
+		{
+			name:      "should return err when schema is nil",
+			schema:    nil,
+			content:   "NAME\nAnn\n",
+			expectedR: nil,
+			expectedE: errors.New("schema is required"),
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			err := os.WriteFile("./validate_test.csv", []byte(tc.content), 0644)
+			if err != nil {
+				panic(err)
+			}
+			defer os.Remove("./validate_test.csv")
+
+			ops := tc.ops
+			if ops == nil {
+				ops = &Options{SkipHeader: true, FieldsPerRecord: -1}
+			}
+			r, e := Validate("./validate_test.csv", tc.schema, ops)
+			if fmt.Sprintf("%v", tc.expectedE) != fmt.Sprintf("%v", e) {
+				t.Errorf("must:%v, but got: %v", tc.expectedE, e)
+			}
+			if fmt.Sprintf("%+v", tc.expectedR) != fmt.Sprintf("%+v", r) {
+				t.Errorf("must:%+v, but got: %+v", tc.expectedR, r)
+			}
+		})
+	}
+}
@@ -0,0 +1,368 @@
+package csvtogo
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"math"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// xlsxDateFormats are the built-in number formats of date and time, see ECMA-376 18.8.30
+var xlsxDateFormats = map[int]bool{
+	14: true, 15: true, 16: true, 17: true, 18: true, 19: true, 20: true, 21: true, 22: true,
+	27: true, 30: true, 36: true, 45: true, 46: true, 47: true, 50: true, 57: true,
+}
+
+type xlsxWorkbook struct {
+	Pr struct {
+		Date1904 bool `xml:"date1904,attr"`
+	} `xml:"workbookPr"`
+	Sheets []struct {
+		Name string `xml:"name,attr"`
+		ID   string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+	} `xml:"sheets>sheet"`
+}
+
+type xlsxRelationships struct {
+	Relationships []struct {
+		ID     string `xml:"Id,attr"`
+		Target string `xml:"Target,attr"`
+	} `xml:"Relationship"`
+}
+
+type xlsxStyles struct {
+	NumFmts []struct {
+		ID   int    `xml:"numFmtId,attr"`
+		Code string `xml:"formatCode,attr"`
+	} `xml:"numFmts>numFmt"`
+	CellXfs []struct {
+		NumFmtID int `xml:"numFmtId,attr"`
+	} `xml:"cellXfs>xf"`
+}
+
+// xlsxText is the text of shared string or inline string, rich text is the list of runs
+type xlsxText struct {
+	T    string `xml:"t"`
+	Runs []struct {
+		T string `xml:"t"`
+	} `xml:"r"`
+}
+
+func (t xlsxText) String() string {
+	if len(t.Runs) == 0 {
+		return t.T
+	}
+	var b strings.Builder
+	for _, r := range t.Runs {
+		b.WriteString(r.T)
+	}
+	return b.String()
+}
+
+type xlsxRow struct {
+	R     int `xml:"r,attr"`
+	Cells []struct {
+		R  string    `xml:"r,attr"`
+		T  string    `xml:"t,attr"`
+		S  int       `xml:"s,attr"`
+		V  string    `xml:"v"`
+		Is *xlsxText `xml:"is"`
+	} `xml:"c"`
+}
+
+// xlsxBook is the parts of workbook that needed to read the cell value
+type xlsxBook struct {
+	strings []string
+	dates   map[int]bool //style index of date cell
+	base    time.Time    //the date of serial 0
+}
+
+// xlsxReader read the sheet of Options.Sheet, or the first sheet if empty, each row is set in order.
+// The row is the row number of spreadsheet, the first non empty row is the header when SkipHeader is true.
+func xlsxReader[T any](r io.Reader, ops Options, ref T, s setters[T]) error {
+	//xlsx is zip archive, it need random access
+	ra, size, err := readerAt(bufio.NewReader(r), r)
+	if err != nil {
+		return err
+	}
+	zr, err := zip.NewReader(ra, size)
+	if err != nil {
+		return fmt.Errorf("invalid xlsx file: %v", err)
+	}
+	book, sheet, err := openBook(zr, ops.Sheet)
+	if err != nil {
+		return err
+	}
+	f, err := zr.Open(sheet)
+	if err != nil {
+		return fmt.Errorf("invalid xlsx file: %v", err)
+	}
+	defer f.Close()
+	s.begin(FormatXLSX)
+
+	dec := xml.NewDecoder(f)
+	width := 0
+	header := ops.SkipHeader
+	rowNum := 0
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			//no more content
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("invalid xlsx file: %v", err)
+		}
+		se, ok := tok.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		switch se.Name.Local {
+		case "dimension":
+			for _, a := range se.Attr {
+				if a.Name.Local == "ref" {
+					first, last, ok := strings.Cut(a.Value, ":")
+					if !ok {
+						last = first
+					}
+					if col, _, ok := cellIndex(last); ok {
+						width = col + 1
+					}
+				}
+			}
+		case "row":
+			var row xlsxRow
+			err = dec.DecodeElement(&row, &se)
+			if err != nil {
+				return fmt.Errorf("invalid xlsx file: %v", err)
+			}
+			rowNum++
+			if row.R > 0 {
+				rowNum = row.R
+			}
+			d := book.values(row)
+			if isEmptyRow(d) {
+				continue
+			}
+			if width == 0 {
+				//no dimension, the width is the first row
+				width = len(d)
+			}
+			for len(d) < width {
+				d = append(d, "")
+			}
+
+			switch {
+			case header:
+				//header is row 0 like csv
+				header = false
+				_, err = s.value(ref, d, 0)
+			case ops.keepRaw(d):
+				var v *T
+				v, err = s.value(ref, d, rowNum)
+				err = ops.skipInvalid(err)
+				if err == nil && v != nil {
+					err = s.send(v, rowNum)
+				}
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// openBook read shared strings and styles of workbook, then return the path of sheet
+func openBook(zr *zip.Reader, sheetName string) (*xlsxBook, string, error) {
+	var wb xlsxWorkbook
+	err := decodeXMLPart(zr, "xl/workbook.xml", &wb)
+	if err != nil {
+		return nil, "", fmt.Errorf("invalid xlsx file: %v", err)
+	}
+	var rels xlsxRelationships
+	err = decodeXMLPart(zr, "xl/_rels/workbook.xml.rels", &rels)
+	if err != nil {
+		return nil, "", fmt.Errorf("invalid xlsx file: %v", err)
+	}
+	if len(wb.Sheets) == 0 {
+		return nil, "", fmt.Errorf("no sheet found in xlsx file")
+	}
+
+	id := ""
+	for _, s := range wb.Sheets {
+		if sheetName == "" || s.Name == sheetName {
+			id = s.ID
+			break
+		}
+	}
+	if id == "" {
+		return nil, "", fmt.Errorf("sheet %v is not found in xlsx file", sheetName)
+	}
+	//the target is relative to xl/ or absolute path in package
+	sheet := ""
+	for _, r := range rels.Relationships {
+		if r.ID == id {
+			sheet = r.Target
+		}
+	}
+	if strings.HasPrefix(sheet, "/") {
+		sheet = strings.TrimPrefix(sheet, "/")
+	} else {
+		sheet = path.Join("xl", sheet)
+	}
+
+	book := &xlsxBook{
+		dates: make(map[int]bool),
+		base:  time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC),
+	}
+	if wb.Pr.Date1904 {
+		book.base = time.Date(1904, 1, 1, 0, 0, 0, 0, time.UTC)
+	}
+
+	//shared strings and styles are optional
+	var sst struct {
+		Items []xlsxText `xml:"si"`
+	}
+	err = decodeXMLPart(zr, "xl/sharedStrings.xml", &sst)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, "", fmt.Errorf("invalid xlsx file: %v", err)
+	}
+	for _, si := range sst.Items {
+		book.strings = append(book.strings, si.String())
+	}
+
+	var styles xlsxStyles
+	err = decodeXMLPart(zr, "xl/styles.xml", &styles)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, "", fmt.Errorf("invalid xlsx file: %v", err)
+	}
+	custom := make(map[int]bool)
+	for _, f := range styles.NumFmts {
+		custom[f.ID] = isDateFormat(f.Code)
+	}
+	for i, xf := range styles.CellXfs {
+		if isDate, ok := custom[xf.NumFmtID]; ok {
+			book.dates[i] = isDate
+			continue
+		}
+		book.dates[i] = xlsxDateFormats[xf.NumFmtID]
+	}
+	return book, sheet, nil
+}
+
+// values return the text of each cell, the missing cell is empty
+func (b *xlsxBook) values(row xlsxRow) []string {
+	var d []string
+	col := -1
+	for _, c := range row.Cells {
+		col++
+		if i, _, ok := cellIndex(c.R); ok {
+			col = i
+		}
+		for len(d) < col {
+			d = append(d, "")
+		}
+
+		val := c.V
+		switch c.T {
+		case "s":
+			i, err := strconv.Atoi(c.V)
+			if err == nil && i >= 0 && i < len(b.strings) {
+				val = b.strings[i]
+			}
+		case "inlineStr":
+			if c.Is != nil {
+				val = c.Is.String()
+			}
+		case "b":
+			val = strconv.FormatBool(c.V == "1")
+		case "", "n":
+			f, err := strconv.ParseFloat(c.V, 64)
+			if err != nil {
+				break
+			}
+			if b.dates[c.S] {
+				val = b.date(f).Format(time.RFC3339)
+				break
+			}
+			//avoid exponent such as 1E-3
+			val = strconv.FormatFloat(f, 'f', -1, 64)
+		}
+		d = append(d, val)
+	}
+	return d
+}
+
+// date convert serial number of date cell to time, the fraction is the time of day
+func (b *xlsxBook) date(serial float64) time.Time {
+	days := math.Floor(serial)
+	ms := math.Round((serial - days) * 24 * 60 * 60 * 1000)
+	return b.base.AddDate(0, 0, int(days)).Add(time.Duration(ms) * time.Millisecond)
+}
+
+// isDateFormat return true if custom format code has date or time part such as yyyy-mm-dd
+func isDateFormat(code string) bool {
+	var b strings.Builder
+	quoted, bracket := false, false
+	for _, r := range code {
+		switch {
+		case r == '"':
+			quoted = !quoted
+		case quoted:
+		case r == '[':
+			bracket = true
+		case r == ']':
+			bracket = false
+		case !bracket:
+			b.WriteRune(r)
+		}
+	}
+	s := strings.ToLower(b.String())
+	if s == "general" {
+		return false
+	}
+	return strings.ContainsAny(s, "ymdhs")
+}
+
+// cellIndex convert cell reference such as B3 to zero based column and row number
+func cellIndex(ref string) (int, int, bool) {
+	col, i := 0, 0
+	for ; i < len(ref) && ref[i] >= 'A' && ref[i] <= 'Z'; i++ {
+		col = col*26 + int(ref[i]-'A'+1)
+	}
+	if i == 0 {
+		return 0, 0, false
+	}
+	row, _ := strconv.Atoi(ref[i:])
+	return col - 1, row, true
+}
+
+func isEmptyRow(d []string) bool {
+	for _, val := range d {
+		if val != "" {
+			return false
+		}
+	}
+	return true
+}
+
+func decodeXMLPart(zr *zip.Reader, name string, v interface{}) error {
+	f, err := zr.Open(name)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	err = xml.NewDecoder(f).Decode(v)
+	if err != nil {
+		return fmt.Errorf("%v: %v", name, err)
+	}
+	return nil
+}
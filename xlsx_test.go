@@ -0,0 +1,152 @@
+package csvtogo
+
+import (
+	"fmt"
+	"os"
+	"testing"
+	"time"
+)
+
+// genXlsx create minimal xlsx file, sheets are the sheetData of each sheet in order
+func genXlsx(filename string, names []string, sheets []string) {
+	workbook := `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets>`
+	rels := `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">`
+	files := map[string]string{}
+	order := []string{"xl/workbook.xml", "xl/_rels/workbook.xml.rels", "xl/sharedStrings.xml", "xl/styles.xml"}
+	for i, name := range names {
+		workbook += fmt.Sprintf(`<sheet name="%v" sheetId="%v" r:id="rId%v"/>`, name, i+1, i+1)
+		rels += fmt.Sprintf(`<Relationship Id="rId%v" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet%v.xml"/>`, i+1, i+1)
+		sheet := fmt.Sprintf("xl/worksheets/sheet%v.xml", i+1)
+		files[sheet] = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>` + sheets[i] + `</sheetData></worksheet>`
+		order = append(order, sheet)
+	}
+	files["xl/workbook.xml"] = workbook + `</sheets></workbook>`
+	files["xl/_rels/workbook.xml.rels"] = rels + `</Relationships>`
+	files["xl/sharedStrings.xml"] = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<sst xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><si><t>name</t></si><si><t>age</t></si><si><t>birthday</t></si><si><r><t>act</t></r><r><t>ive</t></r></si><si><t>Jonathan</t></si></sst>`
+	//style 1 is built-in date format, style 2 is custom date time format
+	files["xl/styles.xml"] = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><numFmts count="1"><numFmt numFmtId="164" formatCode="yyyy\-mm\-dd hh:mm"/></numFmts>
+<cellXfs count="3"><xf numFmtId="0"/><xf numFmtId="14"/><xf numFmtId="164"/></cellXfs></styleSheet>`
+	genZip(filename, files, order)
+}
+
+func Test_CsvToStruct_xlsx(t *testing.T) {
+	type Customer struct {
+		Name     string    `csv:"name" max:"5"`
+		Age      int       `csv:"age"`
+		Birthday time.Time `csv:"birthday"`
+		Active   bool      `csv:"active"`
+	}
+	header := `<row r="1"><c r="A1" t="s"><v>0</v></c><c r="B1" t="s"><v>1</v></c><c r="C1" t="s"><v>2</v></c><c r="D1" t="s"><v>3</v></c></row>`
+	sarah := `<row r="2"><c r="A2" t="inlineStr"><is><t>Sarah</t></is></c><c r="B2"><v>12</v></c><c r="C2" s="1"><v>44562</v></c><c r="D2" t="b"><v>1</v></c></row>`
+	tt := []struct {
+		name      string
+		ops       []*Options
+		sheets    []string
+		expectedR []Customer
+		expectedE error
+	}{
+		{
+			name:   "should read cell types and skip empty row",
+			sheets: []string{header + sarah + `<row r="3"/><row r="4"><c r="A4" t="inlineStr"><is><t>John</t></is></c><c r="B4"><v>1E1</v></c><c r="C4" s="2"><v>44562.5</v></c><c r="D4" t="b"><v>0</v></c></row>`},
+			expectedR: []Customer{
+				{Name: "Sarah", Age: 12, Birthday: time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC), Active: true},
+				{Name: "John", Age: 10, Birthday: time.Date(2022, 1, 1, 12, 0, 0, 0, time.UTC)},
+			},
+		},
+		{
+			name:      "should return error with spreadsheet row number",
+			sheets:    []string{header + sarah + `<row r="5"><c r="A5" t="s"><v>4</v></c><c r="B5"><v>1</v></c><c r="C5" s="1"><v>1</v></c><c r="D5" t="b"><v>0</v></c></row>`},
+			expectedE: fmt.Errorf("value of Name at row 5 is invalid, value length must less than or equal 5, but got: 8"),
+		},
+		{
+			name:      "should read the sheet of options",
+			ops:       []*Options{{SkipHeader: false, SkipCols: []int{0}, Sheet: "Other"}},
+			sheets:    []string{header, `<row r="1"><c r="A1"><v>1</v></c><c r="B1" t="inlineStr"><is><t>Sarah</t></is></c><c r="C1"><v>12</v></c><c r="D1" s="1"><v>44562</v></c><c r="E1" t="b"><v>0</v></c></row>`},
+			expectedR: []Customer{{Name: "Sarah", Age: 12, Birthday: time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)}},
+		},
+		{
+			name:      "should return error when sheet is not found",
+			ops:       []*Options{{SkipHeader: true, Sheet: "Unknown"}},
+			sheets:    []string{header},
+			expectedE: fmt.Errorf("sheet Unknown is not found in xlsx file"),
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			names := []string{"Customer", "Other"}[:len(tc.sheets)]
+			genXlsx("./xlsx_test.xlsx", names, tc.sheets)
+			defer os.Remove("./xlsx_test.xlsx")
+
+			c, _ := NewClient[Customer]("./xlsx_test.xlsx", tc.ops...)
+			r, e := c.CsvToStruct()
+			if fmt.Sprintf("%v", tc.expectedE) != fmt.Sprintf("%v", e) {
+				t.Errorf("must:%v, but got: %v", tc.expectedE, e)
+			}
+			if len(tc.expectedR) != len(r) {
+				t.Fatalf("must:%v, but got: %v", len(tc.expectedR), len(r))
+			}
+			for i, val := range r {
+				if fmt.Sprintf("%v", tc.expectedR[i]) != fmt.Sprintf("%v", *val) {
+					t.Errorf("must:%v, but got: %v", tc.expectedR[i], *val)
+				}
+			}
+		})
+	}
+}
+
+func Test_CsvToStruct_xlsxLayout(t *testing.T) {
+	type Customer struct {
+		Name     string    `csv:"name"`
+		Birthday time.Time `csv:"birthday" layout:"2006-01-02"`
+	}
+	header := `<row r="1"><c r="A1" t="s"><v>0</v></c><c r="B1" t="s"><v>2</v></c></row>`
+	rows := `<row r="2"><c r="A2" t="inlineStr"><is><t>Sarah</t></is></c><c r="B2" s="1"><v>44562</v></c></row>` +
+		`<row r="3"><c r="A3" t="inlineStr"><is><t>John</t></is></c><c r="B3" t="inlineStr"><is><t>2022-02-03</t></is></c></row>`
+	genXlsx("./xlsx_layout_test.xlsx", []string{"Customer"}, []string{header + rows})
+	defer os.Remove("./xlsx_layout_test.xlsx")
+
+	c, _ := NewClient[Customer]("./xlsx_layout_test.xlsx")
+	r, e := c.CsvToStruct()
+	if e != nil {
+		t.Fatalf("must:nil, but got: %v", e)
+	}
+	expectedR := []Customer{
+		{Name: "Sarah", Birthday: time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{Name: "John", Birthday: time.Date(2022, 2, 3, 0, 0, 0, 0, time.UTC)},
+	}
+	if len(expectedR) != len(r) {
+		t.Fatalf("must:%v, but got: %v", len(expectedR), len(r))
+	}
+	for i, val := range r {
+		if fmt.Sprintf("%v", expectedR[i]) != fmt.Sprintf("%v", *val) {
+			t.Errorf("must:%v, but got: %v", expectedR[i], *val)
+		}
+	}
+}
+
+func Test_isDateFormat(t *testing.T) {
+	tt := []struct {
+		name      string
+		code      string
+		expectedR bool
+	}{
+		{name: "should be date when has date part", code: `dd/mm/yyyy`, expectedR: true},
+		{name: "should be date when has time part", code: `[$-409]h:mm AM/PM`, expectedR: true},
+		{name: "should not be date when letter is quoted", code: `0.00" days"`, expectedR: false},
+		{name: "should not be date when general", code: `General`, expectedR: false},
+		{name: "should not be date when color", code: `[Red]0.00`, expectedR: false},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			r := isDateFormat(tc.code)
+			if tc.expectedR != r {
+				t.Errorf("must:%v, but got: %v", tc.expectedR, r)
+			}
+		})
+	}
+}